@@ -20,6 +20,7 @@ var maxRenderTime = time.Duration(1 * time.Second)
 func init() {
 	CheckCmd.Flags().BoolVarP(&rflag, "recursive", "r", false, "find apps recursively")
 	CheckCmd.Flags().DurationVarP(&maxRenderTime, "max-render-time", "", maxRenderTime, "override the default max render time")
+	CheckCmd.Flags().StringVarP(&simulatedTime, "time", "", "", timeFlagUsage)
 }
 
 var CheckCmd = &cobra.Command{
@@ -41,6 +42,11 @@ Discord if you get stuck.`,
 }
 
 func checkCmd(cmd *cobra.Command, args []string) error {
+	timeOpts, err := timeOptions()
+	if err != nil {
+		return err
+	}
+
 	// check every path.
 	foundIssue := false
 	for _, path := range args {
@@ -115,7 +121,7 @@ func checkCmd(cmd *cobra.Command, args []string) error {
 		}
 
 		// Check performance.
-		p, err := ProfileApp(path, map[string]string{})
+		p, err := ProfileApp(path, map[string]string{}, timeOpts...)
 		if err != nil {
 			return fmt.Errorf("could not profile app: %w", err)
 		}
@@ -94,7 +94,7 @@ func profile(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func ProfileApp(path string, config map[string]string) (*pprof_profile.Profile, error) {
+func ProfileApp(path string, config map[string]string, opts ...runtime.AppletOption) (*pprof_profile.Profile, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
@@ -115,7 +115,8 @@ func ProfileApp(path string, config map[string]string) (*pprof_profile.Profile,
 	runtime.InitHTTP(cache)
 	runtime.InitCache(cache)
 
-	applet, err := runtime.NewAppletFromFS(path, fsys, runtime.WithPrintDisabled())
+	opts = append([]runtime.AppletOption{runtime.WithPrintDisabled()}, opts...)
+	applet, err := runtime.NewAppletFromFS(path, fsys, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load applet: %w", err)
 	}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"image"
 	"io/fs"
@@ -14,25 +15,56 @@ import (
 
 	"tidbyt.dev/pixlet/encode"
 	"tidbyt.dev/pixlet/globals"
+	"tidbyt.dev/pixlet/playlist"
+	pixletrender "tidbyt.dev/pixlet/render"
 	"tidbyt.dev/pixlet/runtime"
 	"tidbyt.dev/pixlet/tools"
 )
 
 var (
-	output        string
-	magnify       int
-	renderGif     bool
-	maxDuration   int
-	silenceOutput bool
-	width         int
-	height        int
-	timeout       int
+	output         string
+	magnify        int
+	renderGif      bool
+	renderFormat   string
+	renderFrame    int
+	streamTo       string
+	streamProto    string
+	pixelFormat    string
+	refresh        int
+	filterSpecs    []string
+	renderPlaylist bool
+	maxDuration    int
+	silenceOutput  bool
+	printStats     bool
+	debugLayout    bool
+	width          int
+	height         int
+	timeout        int
 )
 
 func init() {
 	RenderCmd.Flags().StringVarP(&output, "output", "o", "", "Path for rendered image")
 	RenderCmd.Flags().BoolVarP(&renderGif, "gif", "", false, "Generate GIF instead of WebP")
+	RenderCmd.Flags().StringVarP(
+		&renderFormat,
+		"format",
+		"f",
+		"",
+		"Output format: webp, gif, apng, raw, sprite or png (defaults to webp)",
+	)
+	RenderCmd.Flags().IntVarP(
+		&renderFrame,
+		"frame",
+		"",
+		0,
+		"Frame to render, for png format",
+	)
+	RenderCmd.Flags().BoolVarP(&renderPlaylist, "playlist", "", false, "Render a playlist of apps, given as a YAML file, as a single animation")
 	RenderCmd.Flags().BoolVarP(&silenceOutput, "silent", "", false, "Silence print statements when rendering app")
+	RenderCmd.Flags().BoolVarP(&printStats, "stats", "", false, "Print timing and resource usage of the render to stderr")
+	RenderCmd.Flags().StringVarP(&simulatedTime, "time", "", "", timeFlagUsage)
+	RenderCmd.Flags().Int64VarP(&seed, "seed", "", 0, seedFlagUsage)
+	RenderCmd.Flags().BoolVarP(&debugLayout, "debug-layout", "", false, "Render the frame given by --frame as a magnified PNG with every widget outlined, and list the widgets on stderr")
 	RenderCmd.Flags().IntVarP(
 		&magnify,
 		"magnify",
@@ -68,6 +100,51 @@ func init() {
 		30000,
 		"Timeout for execution (ms)",
 	)
+	RenderCmd.Flags().StringArrayVarP(
+		&filterSpecs,
+		"filter",
+		"",
+		nil,
+		"Postprocess frames with gamma=G, white_balance=R,G,B, brightness_cap=B or led[=SCALE]. Can be repeated.",
+	)
+	RenderCmd.Flags().StringVarP(
+		&streamTo,
+		"stream",
+		"",
+		"",
+		"Stream raw frames to - (stdout), a file or named pipe, tcp://host:port or udp://host:port",
+	)
+	RenderCmd.Flags().StringVarP(
+		&streamProto,
+		"stream_protocol",
+		"",
+		encode.StreamProtocolRaw,
+		"Protocol for --stream: raw, tpm2 or pixelflut",
+	)
+	RenderCmd.Flags().StringVarP(
+		&pixelFormat,
+		"pixel_format",
+		"",
+		encode.PixelFormatRGB888,
+		"Pixel format for --stream: rgb888 or rgb565",
+	)
+	RenderCmd.Flags().IntVarP(
+		&refresh,
+		"refresh",
+		"",
+		60000,
+		"How often to run the app again when streaming (ms)",
+	)
+}
+
+// File extensions of the formats supported by render.
+var renderFormatExtensions = map[string]string{
+	"webp":   ".webp",
+	"gif":    ".gif",
+	"apng":   ".png",
+	"raw":    ".raw",
+	"sprite": ".png",
+	"png":    ".png",
 }
 
 var RenderCmd = &cobra.Command{
@@ -94,7 +171,12 @@ func render(cmd *cobra.Command, args []string) error {
 
 	var fs fs.FS
 	var outPath string
-	if info.IsDir() {
+	if renderPlaylist {
+		if info.IsDir() {
+			return fmt.Errorf("playlist must be a file: %s", path)
+		}
+		outPath = strings.TrimSuffix(path, filepath.Ext(path))
+	} else if info.IsDir() {
 		fs = os.DirFS(path)
 		outPath = filepath.Join(path, filepath.Base(path))
 	} else {
@@ -106,17 +188,46 @@ func render(cmd *cobra.Command, args []string) error {
 		outPath = strings.TrimSuffix(path, ".star")
 	}
 
-	if renderGif {
-		outPath += ".gif"
-	} else {
-		outPath += ".webp"
+	format := renderFormat
+	if format == "" {
+		format = "webp"
+		if renderGif {
+			format = "gif"
+		}
+	} else if renderGif && format != "gif" {
+		return fmt.Errorf("--gif conflicts with --format %s", format)
+	}
+
+	ext, ok := renderFormatExtensions[format]
+	if !ok {
+		return fmt.Errorf("unknown format: %s", format)
+	}
+	if debugLayout {
+		if renderFormat != "" && format != "png" {
+			return fmt.Errorf("--debug-layout always renders a png, not %s", format)
+		}
+		ext = ".layout.png"
 	}
+	outPath += ext
 	if output != "" {
 		outPath = output
 	}
 
+	filters, err := encode.ParseImageFilters(filterSpecs)
+	if err != nil {
+		return err
+	}
+	// Magnify last, so that the other filters see the display's pixels
+	filters = append(filters, magnifyFilter)
+
 	globals.Width = width
 	globals.Height = height
+	pixletrender.FrameWidth = width
+	pixletrender.FrameHeight = height
+
+	if renderPlaylist && len(args) > 1 {
+		return fmt.Errorf("config parameters can't be given with --playlist, set them in the playlist instead")
+	}
 
 	config := map[string]string{}
 	for _, param := range args[1:] {
@@ -134,77 +245,122 @@ func render(cmd *cobra.Command, args []string) error {
 		opts = append(opts, runtime.WithPrintDisabled())
 	}
 
-	ctx := context.Background()
-	if timeout > 0 {
-		ctx, _ = context.WithTimeoutCause(
-			ctx,
-			time.Duration(timeout)*time.Millisecond,
-			fmt.Errorf("timeout after %dms", timeout),
-		)
+	timeOpts, err := timeOptions()
+	if err != nil {
+		return err
 	}
+	opts = append(opts, timeOpts...)
+	opts = append(opts, seedOptions(cmd)...)
+
+	if printStats && streamTo != "" {
+		return fmt.Errorf("--stats can't be used with --stream")
+	}
+	if debugLayout && streamTo != "" {
+		return fmt.Errorf("--debug-layout can't be used with --stream")
+	}
+	stats := &runtime.RunStats{}
 
 	cache := runtime.NewInMemoryCache()
 	runtime.InitHTTP(cache)
 	runtime.InitCache(cache)
 
-	applet, err := runtime.NewAppletFromFS(filepath.Base(path), fs, opts...)
-	if err != nil {
-		return fmt.Errorf("failed to load applet: %w", err)
-	}
-
-	roots, err := applet.RunWithConfig(ctx, config)
-	if err != nil {
-		return fmt.Errorf("error running script: %w", err)
-	}
-	screens := encode.ScreensFromRoots(roots)
+	var run func() (*encode.Screens, error)
+	if renderPlaylist {
+		p, err := playlist.LoadPlaylistFile(path)
+		if err != nil {
+			return err
+		}
 
-	filter := func(input image.Image) (image.Image, error) {
-		if magnify <= 1 {
-			return input, nil
+		run = func() (*encode.Screens, error) {
+			ctx := runtime.WithRunStats(context.Background(), stats)
+			roots, err := p.Roots(ctx, time.Duration(timeout)*time.Millisecond, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("error running playlist: %w", err)
+			}
+			return encode.ScreensFromRoots(roots), nil
 		}
-		in, ok := input.(*image.RGBA)
-		if !ok {
-			return nil, fmt.Errorf("image not RGBA, very weird")
+	} else {
+		applet, err := runtime.NewAppletFromFS(filepath.Base(path), fs, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to load applet: %w", err)
 		}
 
-		out := image.NewRGBA(
-			image.Rect(
-				0, 0,
-				in.Bounds().Dx()*magnify,
-				in.Bounds().Dy()*magnify),
-		)
-		for x := 0; x < in.Bounds().Dx(); x++ {
-			for y := 0; y < in.Bounds().Dy(); y++ {
-				for xx := 0; xx < magnify; xx++ {
-					for yy := 0; yy < magnify; yy++ {
-						out.SetRGBA(
-							x*magnify+xx,
-							y*magnify+yy,
-							in.RGBAAt(x, y),
-						)
-					}
-				}
+		run = func() (*encode.Screens, error) {
+			ctx := runtime.WithRunStats(context.Background(), stats)
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeoutCause(
+					ctx,
+					time.Duration(timeout)*time.Millisecond,
+					fmt.Errorf("timeout after %dms", timeout),
+				)
+				defer cancel()
 			}
+
+			roots, err := applet.RunWithConfig(ctx, config)
+			if err != nil {
+				return nil, fmt.Errorf("error running script: %w", err)
+			}
+			return encode.ScreensFromRoots(roots), nil
 		}
+	}
 
-		return out, nil
+	if streamTo != "" {
+		return streamScreens(run, filters)
+	}
+
+	screens, err := run()
+	if err != nil {
+		return err
+	}
+
+	if debugLayout {
+		return writeLayout(outPath, screens)
 	}
 
 	var buf []byte
+	var sheet *encode.SpriteSheet
 
-	if screens.ShowFullAnimation {
+	// Playlists decide for themselves how long each app is shown
+	if screens.ShowFullAnimation || renderPlaylist {
 		maxDuration = 0
 	}
 
-	if renderGif {
-		buf, err = screens.EncodeGIF(maxDuration, filter)
-	} else {
-		buf, err = screens.EncodeWebP(maxDuration, filter)
+	encodeStart := time.Now()
+
+	switch format {
+	case "gif":
+		buf, err = screens.EncodeGIF(maxDuration, filters...)
+	case "apng":
+		buf, err = screens.EncodeAPNG(maxDuration, filters...)
+	case "raw":
+		buf, err = screens.EncodeRaw(maxDuration, filters...)
+	case "png":
+		buf, err = screens.EncodePNG(renderFrame, filters...)
+	case "sprite":
+		sheet, err = screens.EncodeSpriteSheet(maxDuration, filters...)
+	default:
+		buf, err = screens.EncodeWebP(maxDuration, filters...)
 	}
 	if err != nil {
 		return fmt.Errorf("error rendering: %w", err)
 	}
 
+	if printStats {
+		stats.PaintTime = screens.PaintTime()
+		stats.EncodeTime = time.Since(encodeStart) - stats.PaintTime
+		stats.Frames = screens.FrameCount()
+		stats.Bytes = len(buf)
+		if sheet != nil {
+			stats.Bytes = len(sheet.Image)
+		}
+		stats.Write(os.Stderr)
+	}
+
+	if sheet != nil {
+		return writeSpriteSheet(outPath, sheet)
+	}
+
 	if outPath == "-" {
 		_, err = os.Stdout.Write(buf)
 	} else {
@@ -217,3 +373,101 @@ func render(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// Magnification of layout images, unless --magnify asks for more.
+const layoutMagnify = 8
+
+// Writes the frame given by --frame to path as an image of its layout,
+// and lists its widgets on stderr, indented by how deeply they're
+// nested.
+func writeLayout(path string, screens *encode.Screens) error {
+	scale := magnify
+	if scale <= 1 {
+		scale = layoutMagnify
+	}
+
+	buf, layout, err := screens.EncodeLayoutPNG(renderFrame, scale)
+	if err != nil {
+		return fmt.Errorf("error rendering: %w", err)
+	}
+
+	for _, w := range layout {
+		fmt.Fprintf(
+			os.Stderr,
+			"%s%s at (%d, %d), %dx%d\n",
+			strings.Repeat("  ", w.Depth),
+			w.Type,
+			w.Bounds.Min.X, w.Bounds.Min.Y,
+			w.Bounds.Dx(), w.Bounds.Dy(),
+		)
+	}
+
+	if path == "-" {
+		_, err = os.Stdout.Write(buf)
+	} else {
+		err = os.WriteFile(path, buf, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %s", path, err)
+	}
+
+	return nil
+}
+
+// Writes a sprite sheet image to path, and its frame metadata to a
+// JSON file next to it.
+func writeSpriteSheet(path string, sheet *encode.SpriteSheet) error {
+	if path == "-" {
+		return fmt.Errorf("sprite sheets can't be written to stdout")
+	}
+
+	metadata, err := json.MarshalIndent(sheet, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling sprite sheet metadata: %w", err)
+	}
+
+	if err := os.WriteFile(path, sheet.Image, 0644); err != nil {
+		return fmt.Errorf("writing %s: %s", path, err)
+	}
+
+	jsonPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+	if err := os.WriteFile(jsonPath, metadata, 0644); err != nil {
+		return fmt.Errorf("writing %s: %s", jsonPath, err)
+	}
+
+	return nil
+}
+
+// Scales frames up by the magnify factor, drawing each pixel as a
+// square.
+func magnifyFilter(input image.Image) (image.Image, error) {
+	if magnify <= 1 {
+		return input, nil
+	}
+	in, ok := input.(*image.RGBA)
+	if !ok {
+		return nil, fmt.Errorf("image not RGBA, very weird")
+	}
+
+	out := image.NewRGBA(
+		image.Rect(
+			0, 0,
+			in.Bounds().Dx()*magnify,
+			in.Bounds().Dy()*magnify),
+	)
+	for x := 0; x < in.Bounds().Dx(); x++ {
+		for y := 0; y < in.Bounds().Dy(); y++ {
+			for xx := 0; xx < magnify; xx++ {
+				for yy := 0; yy < magnify; yy++ {
+					out.SetRGBA(
+						x*magnify+xx,
+						y*magnify+yy,
+						in.RGBAAt(x, y),
+					)
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"tidbyt.dev/pixlet/runtime"
+)
+
+// Seed for all randomness in apps, given with --seed.
+var seed int64
+
+const seedFlagUsage = "Seed all randomness in the app with this, so that every render is the same"
+
+// Returns the options to seed apps with --seed, if it's set.
+func seedOptions(cmd *cobra.Command) []runtime.AppletOption {
+	if !cmd.Flags().Changed("seed") {
+		return nil
+	}
+
+	return []runtime.AppletOption{runtime.WithSeed(seed)}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"tidbyt.dev/pixlet/encode"
 	"tidbyt.dev/pixlet/server"
 )
 
@@ -13,6 +14,9 @@ var (
 	port  int
 	watch bool
 	serveGif bool
+	servePlaylist bool
+	serveWorkspace bool
+	serveMetrics bool
 )
 
 func init() {
@@ -22,6 +26,12 @@ func init() {
 	ServeCmd.Flags().IntVarP(&maxDuration, "max_duration", "d", 15000, "Maximum allowed animation duration (ms)")
 	ServeCmd.Flags().IntVarP(&timeout, "timeout", "", 30000, "Timeout for execution (ms)")
 	ServeCmd.Flags().BoolVarP(&serveGif, "gif", "", false, "Generate GIF instead of WebP")
+	ServeCmd.Flags().BoolVarP(&servePlaylist, "playlist", "", false, "Serve a playlist of apps, given as a YAML file, as a single animation")
+	ServeCmd.Flags().BoolVarP(&serveWorkspace, "workspace", "", false, "Serve every app found in a directory, each under /apps/{id}/")
+	ServeCmd.Flags().StringVarP(&simulatedTime, "time", "", "", timeFlagUsage)
+	ServeCmd.Flags().Int64VarP(&seed, "seed", "", 0, seedFlagUsage)
+	ServeCmd.Flags().BoolVarP(&serveMetrics, "metrics", "", false, "Serve render, HTTP cache, websocket and Go runtime metrics for Prometheus at /metrics")
+	ServeCmd.Flags().StringArrayVarP(&filterSpecs, "filter", "", nil, "Postprocess frames with gamma=G, white_balance=R,G,B, brightness_cap=B or led[=SCALE]. Can be repeated.")
 }
 
 var ServeCmd = &cobra.Command{
@@ -33,7 +43,12 @@ var ServeCmd = &cobra.Command{
 
 The path argument should be the path to the Pixlet program to run. The
 program can be a single file with the .star extension, or a directory
-containing multiple Starlark files and resources.`,
+containing multiple Starlark files and resources.
+
+With --workspace, the path is a directory of apps instead. Every
+directory in it with a manifest.yaml, or a .star file defining main(),
+is served as an app under /apps/{id}/, and the root page lists all
+apps with live thumbnails.`,
 }
 
 func serve(cmd *cobra.Command, args []string) error {
@@ -41,7 +56,43 @@ func serve(cmd *cobra.Command, args []string) error {
 		fmt.Printf("explicitly setting --watch is unnecessary, since it's the default\n\n")
 	}
 
-	s, err := server.NewServer(host, port, watch, args[0], maxDuration, timeout, serveGif)
+	filters, err := encode.ParseImageFilters(filterSpecs)
+	if err != nil {
+		return err
+	}
+
+	opts, err := timeOptions()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, seedOptions(cmd)...)
+
+	serverOpts := server.Options{
+		Host:          host,
+		Port:          port,
+		Watch:         watch,
+		MaxDuration:   maxDuration,
+		Timeout:       timeout,
+		ServeGif:      serveGif,
+		Filters:       filters,
+		Playlist:      servePlaylist,
+		Metrics:       serveMetrics,
+		AppletOptions: opts,
+	}
+
+	if serveWorkspace {
+		if servePlaylist {
+			return fmt.Errorf("--workspace and --playlist can't be used together")
+		}
+
+		s, err := server.NewWorkspaceServer(args[0], serverOpts)
+		if err != nil {
+			return err
+		}
+		return s.Run()
+	}
+
+	s, err := server.NewServer(args[0], serverOpts)
 	if err != nil {
 		return err
 	}
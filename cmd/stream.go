@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"tidbyt.dev/pixlet/encode"
+)
+
+// Opens the destination of a frame stream: stdout for "-", a socket
+// for tcp:// and udp:// URLs, and otherwise a file or named pipe.
+func openStream(dest string) (io.WriteCloser, error) {
+	switch {
+	case dest == "-":
+		return os.Stdout, nil
+
+	case strings.HasPrefix(dest, "tcp://"), strings.HasPrefix(dest, "udp://"):
+		network, addr, _ := strings.Cut(dest, "://")
+		conn, err := net.Dial(network, addr)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to %s: %w", dest, err)
+		}
+		return conn, nil
+
+	default:
+		// Opening a named pipe blocks until there's a reader
+		f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", dest, err)
+		}
+		return f, nil
+	}
+}
+
+// Streams the frames of the screens returned by run, postprocessed by
+// filters. The animation loops until it's time to call run again.
+// Runs until interrupted, or until the stream can't be written to.
+func streamScreens(run func() (*encode.Screens, error), filters []encode.ImageFilter) error {
+	if refresh <= 0 {
+		return fmt.Errorf("refresh must be positive")
+	}
+
+	out, err := openStream(streamTo)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	fw, err := encode.NewFrameWriter(out, streamProto, pixelFormat)
+	if err != nil {
+		return err
+	}
+
+	for {
+		screens, err := run()
+		if err != nil {
+			return err
+		}
+
+		duration := maxDuration
+		if screens.ShowFullAnimation || renderPlaylist {
+			duration = 0
+		}
+
+		// Loop the animation until it's time to run the app again
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			time.Duration(refresh)*time.Millisecond,
+		)
+		err = screens.Stream(ctx, fw, duration, filters...)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("streaming: %w", err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tidbyt.dev/pixlet/runtime"
+)
+
+// Time to run apps at, given with --time, instead of the actual time.
+var simulatedTime string
+
+const timeFlagUsage = "Run the app as if the current time were this, such as 2025-12-31T23:59:00+09:00 or \"2025-12-31 23:59 Asia/Tokyo\""
+
+// Layouts accepted by --time, besides RFC 3339. They're in local time,
+// unless followed by the name of a time zone.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Parses the value of --time.
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	datetime, loc := value, time.Local
+	if i := strings.LastIndex(value, " "); i >= 0 && i < len(value)-1 {
+		if l, err := time.LoadLocation(value[i+1:]); err == nil {
+			datetime, loc = value[:i], l
+		}
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, datetime, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf(
+		"invalid --time %q, use RFC 3339 such as 2025-12-31T23:59:00+09:00, or a date and time followed by a time zone such as \"2025-12-31 23:59 Asia/Tokyo\"",
+		value,
+	)
+}
+
+// Returns the options to run apps at the time given with --time, if
+// any.
+func timeOptions() ([]runtime.AppletOption, error) {
+	if simulatedTime == "" {
+		return nil, nil
+	}
+
+	t, err := parseTime(simulatedTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return []runtime.AppletOption{runtime.WithTime(t)}, nil
+}
@@ -0,0 +1,173 @@
+package encode
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"tidbyt.dev/pixlet/render"
+)
+
+// Renders a screen to animated PNG. Optionally pass filters for
+// postprocessing each individual frame.
+func (s *Screens) EncodeAPNG(maxDuration int, filters ...ImageFilter) ([]byte, error) {
+	frames, err := s.frames(maxDuration, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(frames) == 0 {
+		return []byte{}, nil
+	}
+
+	return encodeAPNGFrames(frames)
+}
+
+// Renders a single frame of a screen to PNG. Frames are counted as
+// painted, before identical frames are merged.
+func (s *Screens) EncodePNG(frameIdx int, filters ...ImageFilter) ([]byte, error) {
+	images, err := s.render(filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if frameIdx < 0 || frameIdx >= len(images) {
+		return nil, fmt.Errorf("frame %d out of range, have %d frames", frameIdx, len(images))
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, images[frameIdx]); err != nil {
+		return nil, fmt.Errorf("encoding: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeAPNGFrames encodes frames to an animated PNG that loops
+// forever. The first frame doubles as the default image, for
+// viewers without APNG support.
+//
+// All frames must share a color type, which image/png picks per
+// image. Scanlines are therefore compressed here instead.
+func encodeAPNGFrames(frames []frame) ([]byte, error) {
+	bounds := frames[0].image.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	opaque := true
+	for _, f := range frames {
+		if f.image.Bounds().Dx() != width || f.image.Bounds().Dy() != height {
+			return nil, fmt.Errorf("frames differ in size")
+		}
+		if o, ok := f.image.(interface{ Opaque() bool }); !ok || !o.Opaque() {
+			opaque = false
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(render.PNGSignature)
+
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:4], uint32(width))
+	binary.BigEndian.PutUint32(ihdr[4:8], uint32(height))
+	ihdr[8] = 8 // bit depth
+	ihdr[9] = 6 // truecolor with alpha
+	if opaque {
+		ihdr[9] = 2 // truecolor
+	}
+	render.WritePNGChunk(buf, "IHDR", ihdr)
+
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:4], uint32(len(frames)))
+	binary.BigEndian.PutUint32(actl[4:8], 0) // loop forever
+	render.WritePNGChunk(buf, "acTL", actl)
+
+	seq := uint32(0)
+	for i, f := range frames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:4], seq)
+		binary.BigEndian.PutUint32(fctl[4:8], uint32(width))
+		binary.BigEndian.PutUint32(fctl[8:12], uint32(height))
+		num, den := f.duration, 1000
+		if num > 0xffff {
+			// too long to give in milliseconds
+			num, den = f.duration/10, 100
+		}
+		binary.BigEndian.PutUint16(fctl[20:22], uint16(num))
+		binary.BigEndian.PutUint16(fctl[22:24], uint16(den))
+		// x and y offsets, disposal and blend ops are all zero:
+		// each frame replaces the whole canvas.
+		render.WritePNGChunk(buf, "fcTL", fctl)
+		seq++
+
+		data, err := compressPNGImage(f.image, opaque)
+		if err != nil {
+			return nil, fmt.Errorf("compressing frame %d: %w", i, err)
+		}
+
+		if i == 0 {
+			render.WritePNGChunk(buf, "IDAT", data)
+		} else {
+			fdat := make([]byte, 4, 4+len(data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			render.WritePNGChunk(buf, "fdAT", append(fdat, data...))
+			seq++
+		}
+	}
+
+	render.WritePNGChunk(buf, "IEND", nil)
+
+	return buf.Bytes(), nil
+}
+
+// compressPNGImage returns the zlib compressed scanlines of im, as
+// 8 bit RGB or RGBA. Each scanline uses the Sub filter, which does
+// well on the flat colors of a typical app.
+func compressPNGImage(im image.Image, opaque bool) ([]byte, error) {
+	b := im.Bounds()
+	bpp := 4
+	if opaque {
+		bpp = 3
+	}
+
+	buf := &bytes.Buffer{}
+	zw, err := zlib.NewWriterLevel(buf, zlib.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	line := make([]byte, 1+bpp*b.Dx())
+	raw := make([]byte, bpp*b.Dx())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(im.At(x, y)).(color.NRGBA)
+			i := bpp * (x - b.Min.X)
+			raw[i], raw[i+1], raw[i+2] = c.R, c.G, c.B
+			if !opaque {
+				raw[i+3] = c.A
+			}
+		}
+
+		line[0] = 1 // Sub filter
+		for i := range raw {
+			left := byte(0)
+			if i >= bpp {
+				left = raw[i-bpp]
+			}
+			line[1+i] = raw[i] - left
+		}
+
+		if _, err := zw.Write(line); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -1,9 +1,11 @@
 package encode
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"fmt"
 	"image"
+	"time"
 
 	"github.com/vmihailenco/msgpack/v5"
 
@@ -13,13 +15,16 @@ import (
 const (
 	WebPKMin                 = 0
 	WebPKMax                 = 0
-	DefaultScreenDelayMillis = 50
+	DefaultScreenDelayMillis = render.DefaultDelay
 	DefaultMaxAgeSeconds     = 0 // 0 => no max age, cache forever!
 )
 
 type Screens struct {
 	roots             []render.Root
 	images            []image.Image
+	durations         []int
+	bounds            []image.Rectangle
+	paintTime         time.Duration
 	delay             int32
 	MaxAge            int32
 	ShowFullAnimation bool
@@ -59,6 +64,18 @@ func (s *Screens) Empty() bool {
 	return len(s.roots) == 0 && len(s.images) == 0
 }
 
+// PaintTime returns how long it took to paint the roots, once the
+// screen has been encoded.
+func (s *Screens) PaintTime() time.Duration {
+	return s.paintTime
+}
+
+// FrameCount returns the number of frames painted, once the screen has
+// been encoded.
+func (s *Screens) FrameCount() int {
+	return len(s.images)
+}
+
 // Hash returns a hash of the render roots for this screen. This can be used for
 // testing whether two render trees are exactly equivalent, without having to
 // do the actual rendering.
@@ -89,9 +106,40 @@ func (s *Screens) Hash() ([]byte, error) {
 	return h[:], nil
 }
 
+// A rendered frame, along with how long it's displayed in
+// milliseconds.
+type frame struct {
+	image    image.Image
+	duration int
+}
+
 func (s *Screens) render(filters ...ImageFilter) ([]image.Image, error) {
 	if s.images == nil {
-		s.images = render.PaintRoots(true, s.roots...)
+		start := time.Now()
+		for _, r := range s.roots {
+			// Each root keeps its own delay, falling back to
+			// that of the screen.
+			delay := int(s.delay)
+			if r.Delay > 0 {
+				delay = int(r.Delay)
+			}
+
+			images := r.Paint(true)
+
+			// A root's transition leads into it from the root
+			// before it, and runs at the new root's delay. It
+			// paints the whole frame.
+			if r.Transition != nil && len(s.images) > 0 && len(images) > 0 {
+				transition := render.PaintTransition(r.Transition, s.images[len(s.images)-1], images[0], true)
+				images = append(transition, images...)
+			}
+
+			for _, im := range images {
+				s.images = append(s.images, im)
+				s.durations = append(s.durations, delay)
+			}
+		}
+		s.paintTime = time.Since(start)
 	}
 
 	if len(s.images) == 0 {
@@ -116,3 +164,62 @@ func (s *Screens) render(filters ...ImageFilter) ([]image.Image, error) {
 
 	return images, nil
 }
+
+// frames renders the screen, and returns its frames with their
+// durations. Identical consecutive frames are merged into one. If
+// maxDuration is positive, the animation is cut off after that many
+// milliseconds.
+func (s *Screens) frames(maxDuration int, filters ...ImageFilter) ([]frame, error) {
+	images, err := s.render(filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []frame
+	for i, im := range images {
+		duration := int(s.delay)
+		if i < len(s.durations) {
+			duration = s.durations[i]
+		}
+
+		if len(frames) > 0 && sameImage(frames[len(frames)-1].image, im) {
+			frames[len(frames)-1].duration += duration
+			continue
+		}
+		frames = append(frames, frame{image: im, duration: duration})
+	}
+
+	if maxDuration > 0 {
+		remainingDuration := maxDuration
+		for i := range frames {
+			if frames[i].duration >= remainingDuration {
+				frames[i].duration = remainingDuration
+				frames = frames[:i+1]
+				break
+			}
+			remainingDuration -= frames[i].duration
+		}
+	}
+
+	return frames, nil
+}
+
+// Reports whether two images have identical pixels.
+func sameImage(a, b image.Image) bool {
+	if a == b {
+		return true
+	}
+
+	ra, ok := a.(*image.RGBA)
+	if !ok {
+		return false
+	}
+	rb, ok := b.(*image.RGBA)
+	if !ok {
+		return false
+	}
+
+	return ra.Rect == rb.Rect &&
+		ra.Stride == rb.Stride &&
+		bytes.Equal(ra.Pix, rb.Pix)
+}
@@ -71,6 +71,28 @@ def main(config):
     )
 `
 
+// A mostly static app, where only a single line of text scrolls.
+var BenchmarkStaticDotStar = `
+load("render.star", "render")
+
+def main(config):
+    return render.Root(
+        child = render.Column(
+            children = [
+                render.Box(
+                    height = 24,
+                    color = "#002b36",
+                    child = render.Text("72°F", font = "6x13", color = "#b58900"),
+                ),
+                render.Marquee(
+                    width = 64,
+                    child = render.Text("Partly cloudy with a chance of meatballs later in the afternoon", color = "#93a1a1"),
+                ),
+            ],
+        ),
+    )
+`
+
 func BenchmarkRunAndRender(b *testing.B) {
 	app, err := runtime.NewApplet("benchmark.star", []byte(BenchmarkDotStar))
 	if err != nil {
@@ -93,3 +115,68 @@ func BenchmarkRunAndRender(b *testing.B) {
 		}
 	}
 }
+
+// Returns the frames of an app, both as painted and with identical
+// consecutive frames merged.
+func benchmarkFrames(b *testing.B, src string) ([]frame, []frame) {
+	app, err := runtime.NewApplet("benchmark.star", []byte(src))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	roots, err := app.Run(context.Background())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	screens := ScreensFromRoots(roots)
+	merged, err := screens.frames(0)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var painted []frame
+	for _, im := range screens.images {
+		painted = append(painted, frame{image: im, duration: int(screens.delay)})
+	}
+
+	return painted, merged
+}
+
+// Benchmarks encoding with and without frame merging and GIF delta
+// frames. Output size is reported as bytes/image.
+func BenchmarkEncode(b *testing.B) {
+	apps := map[string]string{
+		"average": BenchmarkDotStar,
+		"static":  BenchmarkStaticDotStar,
+	}
+
+	for name, src := range apps {
+		painted, merged := benchmarkFrames(b, src)
+
+		encoders := []struct {
+			name   string
+			encode func() ([]byte, error)
+		}{
+			{"gif/full", func() ([]byte, error) { return encodeGIFFrames(painted, false) }},
+			{"gif/merged", func() ([]byte, error) { return encodeGIFFrames(merged, false) }},
+			{"gif/merged+delta", func() ([]byte, error) { return encodeGIFFrames(merged, true) }},
+			{"webp/full", func() ([]byte, error) { return encodeWebPFrames(painted) }},
+			{"webp/merged", func() ([]byte, error) { return encodeWebPFrames(merged) }},
+		}
+
+		for _, enc := range encoders {
+			b.Run(name+"/"+enc.name, func(b *testing.B) {
+				size := 0
+				for i := 0; i < b.N; i++ {
+					buf, err := enc.encode()
+					if err != nil {
+						b.Fatal(err)
+					}
+					size = len(buf)
+				}
+				b.ReportMetric(float64(size), "bytes/image")
+			})
+		}
+	}
+}
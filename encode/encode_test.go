@@ -3,14 +3,22 @@ package encode
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/draw"
 	"image/gif"
+	"image/png"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidbyt/go-libwebp/webp"
 	"tidbyt.dev/pixlet/render"
+	"tidbyt.dev/pixlet/render/animation"
 	"tidbyt.dev/pixlet/runtime"
 )
 
@@ -213,6 +221,32 @@ func TestHashEmptyApp(t *testing.T) {
 	}
 }
 
+func TestHashWithSeed(t *testing.T) {
+	src := `
+load("render.star", "render")
+load("random.star", "random")
+def main():
+    return render.Root(child=render.Text("%d" % random.number(0, 1 << 30)))
+`
+
+	hashAt := func(now time.Time, seed int64) []byte {
+		app, err := runtime.NewApplet("test.star", []byte(src), runtime.WithTime(now), runtime.WithSeed(seed))
+		require.NoError(t, err)
+
+		roots, err := app.Run(context.Background())
+		require.NoError(t, err)
+
+		hash, err := ScreensFromRoots(roots).Hash()
+		require.NoError(t, err)
+		return hash
+	}
+
+	// Renders with the same seed are the same, whenever they're made
+	now := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, hashAt(now, 4711), hashAt(now.Add(24*time.Hour), 4711))
+	assert.NotEqual(t, hashAt(now, 4711), hashAt(now, 4712))
+}
+
 func TestHashDelayAndMaxAge(t *testing.T) {
 	r := []render.Root{{Child: &render.Text{Content: "derp"}}}
 
@@ -305,32 +339,6 @@ def main():
 	// Source above will produce a 70 frame animation
 	assert.Equal(t, 70, roots[0].Child.FrameCount())
 
-	// These decode gif/webp and return all frame delays and
-	// their sum in milliseconds.
-	gifDelays := func(gifData []byte) []int {
-		im, err := gif.DecodeAll(bytes.NewBuffer(gifData))
-		assert.NoError(t, err)
-		delays := []int{}
-		for _, d := range im.Delay {
-			delays = append(delays, d*10)
-		}
-		return delays
-	}
-	webpDelays := func(webpData []byte) []int {
-		decoder, err := webp.NewAnimationDecoder(webpData)
-		assert.NoError(t, err)
-		img, err := decoder.Decode()
-		assert.NoError(t, err)
-		delays := []int{}
-		last := 0
-		for _, t := range img.Timestamp {
-			d := t - last
-			last = t
-			delays = append(delays, d)
-		}
-		return delays
-	}
-
 	// With 500ms delay per frame, total duration will be
 	// 50000. The encode methods should truncate this down to
 	// whatever fits in the maxDuration.
@@ -340,16 +348,16 @@ def main():
 	assert.NoError(t, err)
 	webpData, err := ScreensFromRoots(roots).EncodeWebP(3000)
 	assert.NoError(t, err)
-	assert.Equal(t, []int{500, 500, 500, 500, 500, 500}, gifDelays(gifData))
-	assert.Equal(t, []int{500, 500, 500, 500, 500, 500}, webpDelays(webpData))
+	assert.Equal(t, []int{500, 500, 500, 500, 500, 500}, gifDelays(t, gifData))
+	assert.Equal(t, []int{500, 500, 500, 500, 500, 500}, webpDelays(t, webpData))
 
 	// 2200 ms -> 5 frames, with last given only 200ms
 	gifData, err = ScreensFromRoots(roots).EncodeGIF(2200)
 	assert.NoError(t, err)
 	webpData, err = ScreensFromRoots(roots).EncodeWebP(2200)
 	assert.NoError(t, err)
-	assert.Equal(t, []int{500, 500, 500, 500, 200}, gifDelays(gifData))
-	assert.Equal(t, []int{500, 500, 500, 500, 200}, webpDelays(webpData))
+	assert.Equal(t, []int{500, 500, 500, 500, 200}, gifDelays(t, gifData))
+	assert.Equal(t, []int{500, 500, 500, 500, 200}, webpDelays(t, webpData))
 
 	// 100 ms -> single frame. Its duration will differ between
 	// gif and webp, but is also irrelevant.
@@ -357,16 +365,16 @@ def main():
 	assert.NoError(t, err)
 	webpData, err = ScreensFromRoots(roots).EncodeWebP(100)
 	assert.NoError(t, err)
-	assert.Equal(t, []int{100}, gifDelays(gifData))
-	assert.Equal(t, []int{0}, webpDelays(webpData))
+	assert.Equal(t, []int{100}, gifDelays(t, gifData))
+	assert.Equal(t, []int{0}, webpDelays(t, webpData))
 
 	// 60000 ms -> all 100 frames, 500 ms each.
 	gifData, err = ScreensFromRoots(roots).EncodeGIF(60000)
 	assert.NoError(t, err)
 	webpData, err = ScreensFromRoots(roots).EncodeWebP(60000)
 	assert.NoError(t, err)
-	assert.Equal(t, gifDelays(gifData), webpDelays(webpData))
-	for _, d := range gifDelays(gifData) {
+	assert.Equal(t, gifDelays(t, gifData), webpDelays(t, webpData))
+	for _, d := range gifDelays(t, gifData) {
 		assert.Equal(t, 500, d)
 	}
 
@@ -375,9 +383,400 @@ def main():
 	assert.NoError(t, err)
 	webpData, err = ScreensFromRoots(roots).EncodeWebP(0)
 	assert.NoError(t, err)
-	assert.Equal(t, gifDelays(gifData), webpDelays(webpData))
-	for _, d := range gifDelays(gifData) {
+	assert.Equal(t, gifDelays(t, gifData), webpDelays(t, webpData))
+	for _, d := range gifDelays(t, gifData) {
 		assert.Equal(t, 500, d)
 	}
 
 }
+
+// These decode gif/webp and return all frame delays in milliseconds.
+func gifDelays(t *testing.T, gifData []byte) []int {
+	im, err := gif.DecodeAll(bytes.NewBuffer(gifData))
+	assert.NoError(t, err)
+	delays := []int{}
+	for _, d := range im.Delay {
+		delays = append(delays, d*10)
+	}
+	return delays
+}
+
+func webpDelays(t *testing.T, webpData []byte) []int {
+	decoder, err := webp.NewAnimationDecoder(webpData)
+	assert.NoError(t, err)
+	img, err := decoder.Decode()
+	assert.NoError(t, err)
+	delays := []int{}
+	last := 0
+	for _, t := range img.Timestamp {
+		d := t - last
+		last = t
+		delays = append(delays, d)
+	}
+	return delays
+}
+
+func TestVariableDelays(t *testing.T) {
+	box := func(c color.Color) render.Widget {
+		return render.Box{Width: 2, Height: 2, Color: c}
+	}
+
+	roots := []render.Root{
+		{
+			Delay: 100,
+			Child: render.Sequence{Children: []render.Widget{
+				render.Hold{Child: box(color.RGBA{0xff, 0, 0, 0xff}), Frames: 10},
+				render.Animation{Children: []render.Widget{
+					box(color.RGBA{0, 0xff, 0, 0xff}),
+					box(color.RGBA{0, 0, 0xff, 0xff}),
+				}},
+			}},
+		},
+		{
+			// Second root keeps its own delay
+			Delay: 30,
+			Child: box(color.RGBA{0xff, 0xff, 0xff, 0xff}),
+		},
+	}
+
+	// The held frames are merged into one
+	gifData, err := ScreensFromRoots(roots).EncodeGIF(0)
+	assert.NoError(t, err)
+	webpData, err := ScreensFromRoots(roots).EncodeWebP(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1000, 100, 100, 30}, gifDelays(t, gifData))
+	assert.Equal(t, []int{1000, 100, 100, 30}, webpDelays(t, webpData))
+
+	// Merged frames are cut short by max duration
+	gifData, err = ScreensFromRoots(roots).EncodeGIF(700)
+	assert.NoError(t, err)
+	webpData, err = ScreensFromRoots(roots).EncodeWebP(700)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{700}, gifDelays(t, gifData))
+	assert.Equal(t, len(gifDelays(t, gifData)), len(webpDelays(t, webpData)))
+}
+
+func TestRootTransitions(t *testing.T) {
+	box := func(c color.Color) render.Widget {
+		return render.Box{Width: 2, Height: 2, Color: c}
+	}
+
+	transition := &animation.Transition{Effect: "dissolve", Duration: 2}
+	require.NoError(t, transition.Init())
+
+	roots := []render.Root{
+		{
+			// Nothing to transition from, so this is ignored
+			Delay:      100,
+			Child:      box(color.RGBA{0xff, 0, 0, 0xff}),
+			Transition: transition,
+		},
+		{
+			// Transition frames run at the delay of the root
+			// they lead into
+			Delay:      40,
+			Child:      box(color.RGBA{0, 0, 0xff, 0xff}),
+			Transition: transition,
+		},
+	}
+
+	gifData, err := ScreensFromRoots(roots).EncodeGIF(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{100, 40, 40, 40}, gifDelays(t, gifData))
+
+	im, err := gif.DecodeAll(bytes.NewReader(gifData))
+	require.NoError(t, err)
+	r, _, b, _ := im.Image[1].At(0, 0).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = im.Image[2].At(0, 0).RGBA()
+	assert.Less(t, r, b)
+}
+
+func TestPaintStats(t *testing.T) {
+	screens := ScreensFromRoots([]render.Root{
+		{Child: render.Box{Width: 2, Height: 2}},
+		{Child: render.Box{Width: 2, Height: 2}},
+	})
+	assert.Equal(t, 0, screens.FrameCount())
+
+	_, err := screens.EncodeGIF(0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, screens.FrameCount())
+	assert.Greater(t, screens.PaintTime().Nanoseconds(), int64(0))
+}
+
+func TestGIFDeltaFrames(t *testing.T) {
+	text := &render.Text{Content: "this text scrolls along", Color: color.RGBA{0xff, 0, 0, 0xff}}
+	require.NoError(t, text.Init())
+
+	roots := []render.Root{{
+		Child: render.Column{Children: []render.Widget{
+			render.Box{Width: 64, Height: 16, Color: color.RGBA{0, 0, 0xff, 0xff}},
+			render.Marquee{
+				Width: 64,
+				Child: text,
+			},
+		}},
+	}}
+
+	screens := ScreensFromRoots(roots)
+	frames, err := screens.frames(0)
+	require.NoError(t, err)
+	require.True(t, len(frames) > 10)
+
+	full, err := encodeGIFFrames(frames, false)
+	require.NoError(t, err)
+	delta, err := encodeGIFFrames(frames, true)
+	require.NoError(t, err)
+	assert.Less(t, len(delta), len(full))
+
+	fullGIF, err := gif.DecodeAll(bytes.NewReader(full))
+	require.NoError(t, err)
+	deltaGIF, err := gif.DecodeAll(bytes.NewReader(delta))
+	require.NoError(t, err)
+	require.Equal(t, len(fullGIF.Image), len(deltaGIF.Image))
+	assert.Equal(t, fullGIF.Delay, deltaGIF.Delay)
+
+	// Only the bottom half of the frames scrolls
+	for _, im := range deltaGIF.Image[1:] {
+		assert.GreaterOrEqual(t, im.Bounds().Min.Y, 16)
+	}
+
+	// Drawing each delta frame on top of the previous ones
+	// reproduces the full frames.
+	canvas := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for i, im := range deltaGIF.Image {
+		draw.Draw(canvas, im.Bounds(), im, im.Bounds().Min, draw.Over)
+
+		expected := image.NewRGBA(canvas.Bounds())
+		draw.Draw(expected, expected.Bounds(), fullGIF.Image[i], image.Point{}, draw.Src)
+		assert.Equal(t, expected.Pix, canvas.Pix, "frame %d", i)
+	}
+}
+
+// Returns roots with a 4 frame animation, where the first two frames
+// are identical.
+func testRoots() []render.Root {
+	box := func(c color.Color) render.Widget {
+		return render.Box{Width: 4, Height: 2, Color: c}
+	}
+
+	return []render.Root{{
+		Delay: 100,
+		Child: render.Animation{Children: []render.Widget{
+			box(color.RGBA{0xff, 0, 0, 0xff}),
+			box(color.RGBA{0xff, 0, 0, 0xff}),
+			box(color.RGBA{0, 0xff, 0, 0xff}),
+			box(color.NRGBA{0, 0, 0xff, 0x80}),
+		}},
+	}}
+}
+
+// Returns a 2x1 image with an opaque and a semi transparent pixel.
+func testTransparentImage() image.Image {
+	im := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	im.SetNRGBA(0, 0, color.NRGBA{0xff, 0, 0, 0xff})
+	im.SetNRGBA(1, 0, color.NRGBA{0, 0, 0xff, 0x80})
+	return im
+}
+
+func TestEncodeAPNG(t *testing.T) {
+	data, err := ScreensFromRoots(testRoots()).EncodeAPNG(0)
+	require.NoError(t, err)
+
+	// Viewers without APNG support show the first frame
+	im, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 32), im.Bounds())
+	assert.Equal(t, color.NRGBA{0xff, 0, 0, 0xff}, color.NRGBAModel.Convert(im.At(0, 0)))
+
+	// The Image widget decodes all frames, with the identical
+	// ones merged.
+	img := &render.Image{Src: string(data)}
+	require.NoError(t, img.Init())
+	assert.Equal(t, 3, img.FrameCount())
+
+	root := render.Root{Delay: 100, Child: img}
+	images := root.Paint(true)
+	require.Equal(t, 4, len(images))
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, images[1].At(0, 0))
+	assert.Equal(t, color.RGBA{0, 0xff, 0, 0xff}, images[2].At(0, 0))
+	assert.Equal(t, color.RGBA{0, 0, 0x80, 0xff}, images[3].At(0, 0))
+
+	// Transparency is kept
+	data, err = ScreensFromImages(testTransparentImage(), testTransparentImage()).EncodeAPNG(0)
+	require.NoError(t, err)
+	im, err = png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, color.NRGBA{0, 0, 0xff, 0x80}, color.NRGBAModel.Convert(im.At(1, 0)))
+
+	data, err = ScreensFromRoots(nil).EncodeAPNG(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(data))
+}
+
+func TestEncodePNG(t *testing.T) {
+	screens := ScreensFromRoots(testRoots())
+
+	data, err := screens.EncodePNG(2)
+	require.NoError(t, err)
+	im, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 32), im.Bounds())
+	assert.Equal(t, color.NRGBA{0, 0xff, 0, 0xff}, color.NRGBAModel.Convert(im.At(3, 1)))
+	assert.Equal(t, color.NRGBA{0, 0, 0, 0xff}, color.NRGBAModel.Convert(im.At(4, 2)))
+
+	_, err = screens.EncodePNG(4)
+	assert.Error(t, err)
+	_, err = screens.EncodePNG(-1)
+	assert.Error(t, err)
+}
+
+func TestPaintedFrames(t *testing.T) {
+	transition := &animation.Transition{Effect: "fade", Duration: 2}
+	require.NoError(t, transition.Init())
+
+	roots := append(testRoots(), render.Root{
+		Delay:      40,
+		Child:      render.Box{Width: 3, Height: 3, Color: color.White},
+		Transition: transition,
+	})
+
+	screens := ScreensFromRoots(roots)
+
+	// Encoding doesn't need bounds, so they aren't worked out
+	_, err := screens.EncodeGIF(0)
+	require.NoError(t, err)
+	assert.Nil(t, screens.bounds)
+
+	frames, err := screens.PaintedFrames()
+	require.NoError(t, err)
+	require.Equal(t, 7, len(frames))
+
+	// Identical frames are kept apart
+	assert.Equal(t, frames[0].Image, frames[1].Image)
+
+	var delays []int
+	var bounds []image.Rectangle
+	for _, f := range frames {
+		delays = append(delays, f.Delay)
+		bounds = append(bounds, f.Bounds)
+		assert.Equal(t, image.Rect(0, 0, 64, 32), f.Image.Bounds())
+	}
+	assert.Equal(t, []int{100, 100, 100, 100, 40, 40, 40}, delays)
+	assert.Equal(t, []image.Rectangle{
+		image.Rect(0, 0, 4, 2),
+		image.Rect(0, 0, 4, 2),
+		image.Rect(0, 0, 4, 2),
+		image.Rect(0, 0, 4, 2),
+		image.Rect(0, 0, 64, 32),
+		image.Rect(0, 0, 64, 32),
+		image.Rect(0, 0, 3, 3),
+	}, bounds)
+}
+
+func TestLayouts(t *testing.T) {
+	transition := &animation.Transition{Effect: "fade", Duration: 2}
+	require.NoError(t, transition.Init())
+
+	roots := append(testRoots(), render.Root{
+		Child:      render.Box{Width: 3, Height: 3, Color: color.White},
+		Transition: transition,
+	})
+
+	layouts := ScreensFromRoots(roots).Layouts()
+	require.Equal(t, 7, len(layouts))
+
+	for i := 0; i < 4; i++ {
+		require.Equal(t, 2, len(layouts[i]))
+		assert.Equal(t, "Animation", layouts[i][0].Type)
+		assert.Equal(t, "Box", layouts[i][1].Type)
+		assert.Equal(t, image.Rect(0, 0, 4, 2), layouts[i][1].Bounds)
+	}
+	assert.Nil(t, layouts[4])
+	assert.Nil(t, layouts[5])
+	assert.Equal(t, []render.WidgetBounds{
+		{Type: "Box", Bounds: image.Rect(0, 0, 3, 3)},
+	}, layouts[6])
+}
+
+// Returns the frame durations of a raw encoded animation.
+func rawDurations(t *testing.T, data []byte) []int {
+	require.True(t, len(data) >= 13)
+	frameSize := 4 + 3*int(binary.BigEndian.Uint16(data[5:7]))*int(binary.BigEndian.Uint16(data[7:9]))
+	count := int(binary.BigEndian.Uint32(data[9:13]))
+	require.Equal(t, 13+count*frameSize, len(data))
+
+	durations := []int{}
+	for i := 0; i < count; i++ {
+		durations = append(durations, int(binary.BigEndian.Uint32(data[13+i*frameSize:])))
+	}
+	return durations
+}
+
+func TestEncodeRaw(t *testing.T) {
+	data, err := ScreensFromRoots(testRoots()).EncodeRaw(0)
+	require.NoError(t, err)
+
+	assert.Equal(t, RawMagic, string(data[0:4]))
+	assert.Equal(t, byte(RawVersion), data[4])
+	assert.Equal(t, []byte{0, 64, 0, 32, 0, 0, 0, 3}, data[5:13])
+	assert.Equal(t, []int{200, 100, 100}, rawDurations(t, data))
+
+	frameSize := 4 + 64*32*3
+	frames := data[13:]
+	assert.Equal(t, []byte{0xff, 0, 0}, frames[4:7])
+	assert.Equal(t, []byte{0, 0, 0}, frames[4+3*4:4+3*5])
+	assert.Equal(t, []byte{0, 0xff, 0}, frames[frameSize+4:frameSize+7])
+	assert.Equal(t, []byte{0, 0, 0x80}, frames[2*frameSize+4:2*frameSize+7])
+
+	// Max duration applies
+	data, err = ScreensFromRoots(testRoots()).EncodeRaw(250)
+	require.NoError(t, err)
+	assert.Equal(t, []int{200, 50}, rawDurations(t, data))
+
+	// Transparent pixels are drawn on black
+	data, err = ScreensFromImages(testTransparentImage()).EncodeRaw(0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0, 0, 0, 0, 0x80}, data[17:])
+}
+
+func TestEncodeSpriteSheet(t *testing.T) {
+	sheet, err := ScreensFromRoots(testRoots()).EncodeSpriteSheet(0)
+	require.NoError(t, err)
+
+	// 3 frames are laid out in a 2x2 grid
+	assert.Equal(t, 128, sheet.Width)
+	assert.Equal(t, 64, sheet.Height)
+	assert.Equal(t, 64, sheet.FrameWidth)
+	assert.Equal(t, 32, sheet.FrameHeight)
+	assert.Equal(t, []SpriteFrame{
+		{X: 0, Y: 0, Duration: 200},
+		{X: 64, Y: 0, Duration: 100},
+		{X: 0, Y: 32, Duration: 100},
+	}, sheet.Frames)
+
+	im, err := png.Decode(bytes.NewReader(sheet.Image))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 128, 64), im.Bounds())
+	assert.Equal(t, color.NRGBA{0xff, 0, 0, 0xff}, color.NRGBAModel.Convert(im.At(0, 0)))
+	assert.Equal(t, color.NRGBA{0, 0xff, 0, 0xff}, color.NRGBAModel.Convert(im.At(64, 0)))
+	assert.Equal(t, color.NRGBA{0, 0, 0x80, 0xff}, color.NRGBAModel.Convert(im.At(0, 32)))
+
+	// The unused cell is left transparent
+	assert.Equal(t, color.NRGBA{0, 0, 0, 0}, color.NRGBAModel.Convert(im.At(64, 32)))
+
+	metadata, err := json.Marshal(sheet)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"width": 128,
+		"height": 64,
+		"frame_width": 64,
+		"frame_height": 32,
+		"frames": [
+			{"x": 0, "y": 0, "duration": 200},
+			{"x": 64, "y": 0, "duration": 100},
+			{"x": 0, "y": 32, "duration": 100}
+		]
+	}`, string(metadata))
+}
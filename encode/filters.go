@@ -0,0 +1,229 @@
+package encode
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultLEDScale is the size of each LED, in pixels, when
+// simulating an LED panel without giving a scale.
+const DefaultLEDScale = 10
+
+// GammaFilter applies a gamma curve to each color channel. A gamma
+// above 1 darkens midtones, which makes LEDs look more like a
+// regular screen.
+func GammaFilter(gamma float64) ImageFilter {
+	var lut [3][256]uint8
+	for i := 0; i < 256; i++ {
+		v := uint8(math.Round(math.Pow(float64(i)/0xff, gamma) * 0xff))
+		lut[0][i], lut[1][i], lut[2][i] = v, v, v
+	}
+	return channelFilter(lut)
+}
+
+// WhiteBalanceFilter scales the red, green and blue channels by the
+// given factors, to correct for LEDs of uneven strength.
+func WhiteBalanceFilter(r, g, b float64) ImageFilter {
+	var lut [3][256]uint8
+	for c, f := range []float64{r, g, b} {
+		for i := 0; i < 256; i++ {
+			lut[c][i] = uint8(math.Round(math.Max(0, math.Min(0xff, float64(i)*f))))
+		}
+	}
+	return channelFilter(lut)
+}
+
+// BrightnessCapFilter scales all colors so that full white ends up
+// at the given brightness, between 0 and 1.
+func BrightnessCapFilter(max float64) ImageFilter {
+	return WhiteBalanceFilter(max, max, max)
+}
+
+// channelFilter maps each color channel through a lookup table.
+// Channels are mapped without premultiplied alpha.
+func channelFilter(lut [3][256]uint8) ImageFilter {
+	return func(input image.Image) (image.Image, error) {
+		in, ok := input.(*image.RGBA)
+		if !ok {
+			return nil, fmt.Errorf("image not RGBA")
+		}
+
+		out := image.NewRGBA(in.Bounds())
+		for y := in.Rect.Min.Y; y < in.Rect.Max.Y; y++ {
+			for x := in.Rect.Min.X; x < in.Rect.Max.X; x++ {
+				i := in.PixOffset(x, y)
+				j := out.PixOffset(x, y)
+				a := uint32(in.Pix[i+3])
+				for c := 0; c < 3; c++ {
+					v := uint32(in.Pix[i+c])
+					if a == 0 {
+						v = 0
+					} else if a < 0xff {
+						v = uint32(lut[c][v*0xff/a]) * a / 0xff
+					} else {
+						v = uint32(lut[c][v])
+					}
+					out.Pix[j+c] = uint8(v)
+				}
+				out.Pix[j+3] = uint8(a)
+			}
+		}
+
+		return out, nil
+	}
+}
+
+// LEDPanelFilter scales images up to preview what they look like on
+// an LED panel. Each pixel turns into a round LED of scale by scale
+// pixels, with a dark gap and a faint glow around it.
+func LEDPanelFilter(scale int) ImageFilter {
+	mask := ledMask(scale)
+
+	return func(input image.Image) (image.Image, error) {
+		in, ok := input.(*image.RGBA)
+		if !ok {
+			return nil, fmt.Errorf("image not RGBA")
+		}
+
+		b := in.Bounds()
+		out := image.NewRGBA(image.Rect(0, 0, b.Dx()*scale, b.Dy()*scale))
+
+		// LEDs are dark when off, so the panel is black
+		for i := 3; i < len(out.Pix); i += 4 {
+			out.Pix[i] = 0xff
+		}
+
+		for y := 0; y < b.Dy(); y++ {
+			for x := 0; x < b.Dx(); x++ {
+				i := in.PixOffset(b.Min.X+x, b.Min.Y+y)
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						m := mask[dy*scale+dx]
+						j := out.PixOffset(x*scale+dx, y*scale+dy)
+						for c := 0; c < 3; c++ {
+							out.Pix[j+c] = uint8(float64(in.Pix[i+c]) * m)
+						}
+					}
+				}
+			}
+		}
+
+		return out, nil
+	}
+}
+
+// ledMask returns the intensity of each pixel of a single LED: full
+// inside the LED, fading out as a glow across the gap around it.
+func ledMask(scale int) []float64 {
+	center := float64(scale) / 2
+	gap := math.Max(1, float64(scale)/5)
+	radius := center - gap/2
+
+	mask := make([]float64, scale*scale)
+	for y := 0; y < scale; y++ {
+		for x := 0; x < scale; x++ {
+			d := math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center)
+
+			// Anti-aliased edge of the LED itself
+			m := math.Max(0, math.Min(1, radius-d+0.5))
+
+			// Glow, at a quarter of the LED's intensity
+			glow := 0.25 * math.Max(0, math.Min(1, 1-(d-radius)/gap))
+			mask[y*scale+x] = math.Max(m, glow)
+		}
+	}
+
+	return mask
+}
+
+// ParseImageFilter returns the filter described by spec, which is
+// its name optionally followed by "=" and its parameters:
+//
+//	gamma=2.2                 GammaFilter
+//	white_balance=1,0.9,0.8   WhiteBalanceFilter
+//	brightness_cap=0.6        BrightnessCapFilter
+//	led or led=8              LEDPanelFilter, defaults to DefaultLEDScale
+func ParseImageFilter(spec string) (ImageFilter, error) {
+	name, value, _ := strings.Cut(spec, "=")
+
+	var params []float64
+	if value != "" {
+		for _, p := range strings.Split(value, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil {
+				return nil, fmt.Errorf("filter %s: invalid parameter %q", name, p)
+			}
+			params = append(params, f)
+		}
+	}
+
+	expect := func(n int) error {
+		if len(params) != n {
+			return fmt.Errorf("filter %s: expected %d parameters, got %d", name, n, len(params))
+		}
+		return nil
+	}
+
+	switch name {
+	case "gamma":
+		if err := expect(1); err != nil {
+			return nil, err
+		}
+		if params[0] <= 0 {
+			return nil, fmt.Errorf("filter gamma: must be positive")
+		}
+		return GammaFilter(params[0]), nil
+
+	case "white_balance":
+		if err := expect(3); err != nil {
+			return nil, err
+		}
+		for _, p := range params {
+			if p < 0 {
+				return nil, fmt.Errorf("filter white_balance: must not be negative")
+			}
+		}
+		return WhiteBalanceFilter(params[0], params[1], params[2]), nil
+
+	case "brightness_cap":
+		if err := expect(1); err != nil {
+			return nil, err
+		}
+		if params[0] < 0 || params[0] > 1 {
+			return nil, fmt.Errorf("filter brightness_cap: must be between 0 and 1")
+		}
+		return BrightnessCapFilter(params[0]), nil
+
+	case "led":
+		scale := DefaultLEDScale
+		if len(params) > 0 {
+			if err := expect(1); err != nil {
+				return nil, err
+			}
+			scale = int(params[0])
+		}
+		if scale < 2 {
+			return nil, fmt.Errorf("filter led: scale must be at least 2")
+		}
+		return LEDPanelFilter(scale), nil
+	}
+
+	return nil, fmt.Errorf("unknown filter: %s", name)
+}
+
+// ParseImageFilters parses a list of filter specs, as described in
+// ParseImageFilter.
+func ParseImageFilters(specs []string) ([]ImageFilter, error) {
+	filters := []ImageFilter{}
+	for _, spec := range specs {
+		f, err := ParseImageFilter(spec)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
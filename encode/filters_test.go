@@ -0,0 +1,105 @@
+package encode
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyFilter(t *testing.T, spec string, im image.Image) *image.RGBA {
+	f, err := ParseImageFilter(spec)
+	require.NoError(t, err)
+	out, err := f(im)
+	require.NoError(t, err)
+	return out.(*image.RGBA)
+}
+
+func TestGammaFilter(t *testing.T) {
+	im := testFrame(1, 1, color.RGBA{0x80, 0xff, 0, 0xff})
+
+	out := applyFilter(t, "gamma=2.2", im)
+	assert.Equal(t, color.RGBA{0x38, 0xff, 0, 0xff}, out.RGBAAt(0, 0))
+
+	out = applyFilter(t, "gamma=1", im)
+	assert.Equal(t, im.Pix, out.Pix)
+
+	// Semi transparent colors are adjusted before alpha is applied
+	im = testFrame(1, 1, color.RGBA{0x40, 0x40, 0x40, 0x80})
+	out = applyFilter(t, "gamma=2.2", im)
+	assert.Equal(t, color.RGBA{0x1b, 0x1b, 0x1b, 0x80}, out.RGBAAt(0, 0))
+}
+
+func TestWhiteBalanceFilter(t *testing.T) {
+	im := testFrame(1, 1, color.RGBA{0xff, 0xff, 0x80, 0xff})
+
+	out := applyFilter(t, "white_balance=1,0.5,1.5", im)
+	assert.Equal(t, color.RGBA{0xff, 0x80, 0xc0, 0xff}, out.RGBAAt(0, 0))
+}
+
+func TestBrightnessCapFilter(t *testing.T) {
+	im := testFrame(1, 1, color.RGBA{0xff, 0x80, 0, 0xff})
+
+	out := applyFilter(t, "brightness_cap=0.5", im)
+	assert.Equal(t, color.RGBA{0x80, 0x40, 0, 0xff}, out.RGBAAt(0, 0))
+}
+
+func TestLEDPanelFilter(t *testing.T) {
+	im := testFrame(2, 1, color.RGBA{0xff, 0, 0, 0xff})
+	im.SetRGBA(1, 0, color.RGBA{0, 0, 0, 0})
+
+	out := applyFilter(t, "led=10", im)
+	assert.Equal(t, image.Rect(0, 0, 20, 10), out.Bounds())
+
+	// The center of each LED has its full color
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, out.RGBAAt(5, 5))
+
+	// The corners are dark, apart from some glow
+	corner := out.RGBAAt(0, 0)
+	assert.Less(t, corner.R, uint8(0x40))
+	assert.Equal(t, uint8(0xff), corner.A)
+
+	// LEDs that are off are black
+	assert.Equal(t, color.RGBA{0, 0, 0, 0xff}, out.RGBAAt(15, 5))
+
+	out = applyFilter(t, "led", im)
+	assert.Equal(t, image.Rect(0, 0, 2*DefaultLEDScale, DefaultLEDScale), out.Bounds())
+}
+
+func TestParseImageFilter(t *testing.T) {
+	for _, spec := range []string{
+		"gamma=2.2",
+		"white_balance=1, 0.9, 0.8",
+		"brightness_cap=0.6",
+		"led",
+		"led=4",
+	} {
+		_, err := ParseImageFilter(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{
+		"",
+		"foo",
+		"gamma",
+		"gamma=0",
+		"gamma=x",
+		"white_balance=1,1",
+		"white_balance=1,-1,1",
+		"brightness_cap=2",
+		"led=1",
+		"led=4,4",
+	} {
+		_, err := ParseImageFilter(spec)
+		assert.Error(t, err, spec)
+	}
+
+	filters, err := ParseImageFilters([]string{"gamma=2.2", "led"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(filters))
+
+	_, err = ParseImageFilters([]string{"gamma=2.2", "foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package encode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	"tidbyt.dev/pixlet/render"
+)
+
+// PaintedFrame is a single frame of a screen, along with how long
+// it's displayed in milliseconds, and the area its root's child
+// painted in.
+type PaintedFrame struct {
+	Image  image.Image
+	Delay  int
+	Bounds image.Rectangle
+}
+
+// PaintedFrames renders the screen, and returns every frame as it was
+// painted. Unlike when encoding, identical consecutive frames are kept
+// apart, and the animation is never cut off. Optionally pass filters
+// for postprocessing each individual frame. Bounds are given before
+// filtering.
+func (s *Screens) PaintedFrames(filters ...ImageFilter) ([]PaintedFrame, error) {
+	images, err := s.render(filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := s.paintBounds()
+
+	frames := make([]PaintedFrame, len(images))
+	for i, im := range images {
+		frames[i] = PaintedFrame{
+			Image:  im,
+			Delay:  int(s.delay),
+			Bounds: s.images[i].Bounds(),
+		}
+		if i < len(s.durations) {
+			frames[i].Delay = s.durations[i]
+		}
+		if i < len(bounds) {
+			frames[i].Bounds = bounds[i]
+		}
+	}
+
+	return frames, nil
+}
+
+// Returns the area painted by the child of each frame's root. They're
+// only worked out when first asked for, since encoding doesn't need
+// them. Frames painted by transitions cover the whole frame.
+func (s *Screens) paintBounds() []image.Rectangle {
+	if s.bounds != nil {
+		return s.bounds
+	}
+
+	for _, r := range s.roots {
+		bounds := r.PaintBounds()
+
+		if r.Transition != nil && len(s.bounds) > 0 && len(bounds) > 0 {
+			for i := 0; i < r.Transition.FrameCount(); i++ {
+				s.bounds = append(s.bounds, image.Rect(0, 0, render.FrameWidth, render.FrameHeight))
+			}
+		}
+		s.bounds = append(s.bounds, bounds...)
+	}
+
+	return s.bounds
+}
+
+// Layouts paints the roots of the screen again, recording the type
+// and bounds of every widget in each frame. The layouts line up with
+// the frames returned by PaintedFrames, and frames painted by
+// transitions have no layout.
+func (s *Screens) Layouts() [][]render.WidgetBounds {
+	var layouts [][]render.WidgetBounds
+
+	for _, r := range s.roots {
+		var layout [][]render.WidgetBounds
+		images := r.Paint(true, render.WithLayout(&layout))
+
+		if r.Transition != nil && len(layouts) > 0 && len(images) > 0 {
+			layouts = append(layouts, make([][]render.WidgetBounds, r.Transition.FrameCount())...)
+		}
+		layouts = append(layouts, layout...)
+	}
+
+	return layouts
+}
+
+// EncodeLayoutPNG renders a single frame of the screen as a PNG,
+// magnified by scale, with the bounds of every widget in it outlined
+// and labelled. It returns the frame's layout along with the image.
+func (s *Screens) EncodeLayoutPNG(frameIdx int, scale int) ([]byte, []render.WidgetBounds, error) {
+	frames, err := s.PaintedFrames()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if frameIdx < 0 || frameIdx >= len(frames) {
+		return nil, nil, fmt.Errorf("frame %d out of range, have %d frames", frameIdx, len(frames))
+	}
+
+	layout := s.Layouts()[frameIdx]
+	im := render.PaintLayout(frames[frameIdx].Image, layout, scale)
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, im); err != nil {
+		return nil, nil, fmt.Errorf("encoding: %w", err)
+	}
+
+	return buf.Bytes(), layout, nil
+}
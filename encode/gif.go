@@ -14,49 +14,124 @@ import (
 // Renders a screen to GIF. Optionally pass filters for postprocessing
 // each individual frame.
 func (s *Screens) EncodeGIF(maxDuration int, filters ...ImageFilter) ([]byte, error) {
-	images, err := s.render(filters...)
+	frames, err := s.frames(maxDuration, filters...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(images) == 0 {
+	if len(frames) == 0 {
 		return []byte{}, nil
 	}
 
-	g := &gif.GIF{}
+	return encodeGIFFrames(frames, true)
+}
+
+// encodeGIFFrames encodes frames to GIF. With delta set, frames are
+// encoded as the smallest rectangle covering what changed since the
+// previous frame, with unchanged pixels left transparent.
+func encodeGIFFrames(frames []frame, delta bool) ([]byte, error) {
+	bounds := frames[0].image.Bounds()
+	g := &gif.GIF{
+		Config: image.Config{Width: bounds.Dx(), Height: bounds.Dy()},
+	}
 
-	remainingDuration := maxDuration
-	for imIdx, im := range images {
-		imRGBA, ok := im.(*image.RGBA)
+	var prev *image.RGBA
+	for imIdx, f := range frames {
+		imRGBA, ok := f.image.(*image.RGBA)
 		if !ok {
-			return nil, fmt.Errorf("image %d is %T, require RGBA", imIdx, im)
+			return nil, fmt.Errorf("image %d is %T, require RGBA", imIdx, f.image)
 		}
 
-		palette := quantize.MedianCutQuantizer{}.Quantize(make([]color.Color, 0, 256), im)
-		imPaletted := image.NewPaletted(imRGBA.Bounds(), palette)
-		draw.Draw(imPaletted, imRGBA.Bounds(), imRGBA, image.Point{0, 0}, draw.Src)
-
-		frameDelay := int(s.delay)
-		if maxDuration > 0 {
-			if frameDelay > remainingDuration {
-				frameDelay = remainingDuration
+		// Frames with transparency can't be drawn on top of
+		// the previous one, and are always encoded in full. So
+		// are frames where most pixels changed, since reserving
+		// a palette entry for transparency only costs quality
+		// there.
+		var imPaletted *image.Paletted
+		if delta && prev != nil && imRGBA.Opaque() && prev.Opaque() {
+			changed := changedRect(prev, imRGBA)
+			if 2*area(changed) < area(imRGBA.Rect) {
+				imPaletted = deltaPaletted(prev, imRGBA, changed)
 			}
-			remainingDuration -= frameDelay
+		}
+		if imPaletted == nil {
+			imPaletted = fullPaletted(imRGBA)
 		}
 
 		g.Image = append(g.Image, imPaletted)
-		g.Delay = append(g.Delay, frameDelay/10) // in 100ths of a second
+		g.Delay = append(g.Delay, f.duration/10) // in 100ths of a second
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
 
-		if maxDuration > 0 && remainingDuration <= 0 {
-			break
-		}
+		prev = imRGBA
 	}
 
 	buf := &bytes.Buffer{}
-	err = gif.EncodeAll(buf, g)
+	err := gif.EncodeAll(buf, g)
 	if err != nil {
 		return nil, fmt.Errorf("encoding: %w", err)
 	}
 
 	return buf.Bytes(), nil
 }
+
+func fullPaletted(im *image.RGBA) *image.Paletted {
+	palette := quantize.MedianCutQuantizer{}.Quantize(make([]color.Color, 0, 256), im)
+	imPaletted := image.NewPaletted(im.Bounds(), palette)
+	draw.Draw(imPaletted, im.Bounds(), im, im.Bounds().Min, draw.Src)
+	return imPaletted
+}
+
+// deltaPaletted returns the changed part of im, as found by
+// changedRect. Pixels that are the same as in prev are made
+// transparent.
+func deltaPaletted(prev, im *image.RGBA, changed image.Rectangle) *image.Paletted {
+	if changed.Empty() {
+		// GIF frames can't be empty, so keep a single pixel
+		changed = image.Rect(im.Rect.Min.X, im.Rect.Min.Y, im.Rect.Min.X+1, im.Rect.Min.Y+1)
+	}
+
+	sub := im.SubImage(changed)
+
+	// One palette entry is reserved for transparency
+	palette := quantize.MedianCutQuantizer{}.Quantize(make([]color.Color, 0, 255), sub)
+	palette = append(palette, color.Transparent)
+	transparent := uint8(len(palette) - 1)
+
+	imPaletted := image.NewPaletted(changed, palette)
+	draw.Draw(imPaletted, changed, sub, changed.Min, draw.Src)
+
+	for y := changed.Min.Y; y < changed.Max.Y; y++ {
+		for x := changed.Min.X; x < changed.Max.X; x++ {
+			i := im.PixOffset(x, y)
+			if bytes.Equal(im.Pix[i:i+4], prev.Pix[i:i+4]) {
+				imPaletted.SetColorIndex(x, y, transparent)
+			}
+		}
+	}
+
+	return imPaletted
+}
+
+// changedRect returns the smallest rectangle covering all pixels
+// that differ between two equally sized images.
+func changedRect(a, b *image.RGBA) image.Rectangle {
+	r := image.Rectangle{}
+	for y := b.Rect.Min.Y; y < b.Rect.Max.Y; y++ {
+		row := b.PixOffset(b.Rect.Min.X, y)
+		rowEnd := row + 4*b.Rect.Dx()
+		if bytes.Equal(a.Pix[row:rowEnd], b.Pix[row:rowEnd]) {
+			continue
+		}
+		for x := b.Rect.Min.X; x < b.Rect.Max.X; x++ {
+			i := b.PixOffset(x, y)
+			if !bytes.Equal(a.Pix[i:i+4], b.Pix[i:i+4]) {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+func area(r image.Rectangle) int {
+	return r.Dx() * r.Dy()
+}
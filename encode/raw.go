@@ -0,0 +1,85 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+)
+
+// RawMagic starts every raw encoded animation.
+const RawMagic = "PXRW"
+
+// RawVersion is the version of the raw format written by EncodeRaw.
+const RawVersion = 1
+
+// Renders a screen to a raw stream of RGB frames, for driving LED
+// hardware that can't decode images. Optionally pass filters for
+// postprocessing each individual frame.
+//
+// All integers are big endian. The stream starts with a header:
+//
+//	magic        4 bytes, "PXRW"
+//	version      uint8, currently 1
+//	width        uint16
+//	height       uint16
+//	frame count  uint32
+//
+// Each frame follows as its duration in milliseconds (uint32), and
+// then width*height pixels of 3 bytes each (R, G, B), row by row.
+// Transparent pixels are drawn on black.
+func (s *Screens) EncodeRaw(maxDuration int, filters ...ImageFilter) ([]byte, error) {
+	frames, err := s.frames(maxDuration, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(frames) == 0 {
+		return []byte{}, nil
+	}
+
+	bounds := frames[0].image.Bounds()
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(RawMagic)
+	buf.WriteByte(RawVersion)
+	binary.Write(buf, binary.BigEndian, uint16(bounds.Dx()))
+	binary.Write(buf, binary.BigEndian, uint16(bounds.Dy()))
+	binary.Write(buf, binary.BigEndian, uint32(len(frames)))
+
+	for i, f := range frames {
+		if f.image.Bounds().Size() != bounds.Size() {
+			return nil, fmt.Errorf("frame %d is %v, expected %v", i, f.image.Bounds().Size(), bounds.Size())
+		}
+		binary.Write(buf, binary.BigEndian, uint32(f.duration))
+		buf.Write(rgbPixels(f.image))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rgbPixels returns the pixels of im as RGB triplets, row by row.
+// Colors are premultiplied, which is the same as drawing them on
+// black.
+func rgbPixels(im image.Image) []byte {
+	b := im.Bounds()
+	pix := make([]byte, 0, 3*b.Dx()*b.Dy())
+
+	if rgba, ok := im.(*image.RGBA); ok {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			row := rgba.Pix[rgba.PixOffset(b.Min.X, y):]
+			for x := 0; x < b.Dx(); x++ {
+				pix = append(pix, row[4*x], row[4*x+1], row[4*x+2])
+			}
+		}
+		return pix
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := im.At(x, y).RGBA()
+			pix = append(pix, uint8(r>>8), uint8(g>>8), uint8(bl>>8))
+		}
+	}
+	return pix
+}
@@ -0,0 +1,84 @@
+package encode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// A sprite sheet holds all frames of an animation in a single PNG,
+// laid out left to right and top to bottom. It marshals to JSON as
+// the frame metadata, with the image left out.
+type SpriteSheet struct {
+	Image       []byte        `json:"-"`
+	Width       int           `json:"width"`
+	Height      int           `json:"height"`
+	FrameWidth  int           `json:"frame_width"`
+	FrameHeight int           `json:"frame_height"`
+	Frames      []SpriteFrame `json:"frames"`
+}
+
+// A single frame of a sprite sheet, giving its position in the image
+// and how long it's displayed in milliseconds.
+type SpriteFrame struct {
+	X        int `json:"x"`
+	Y        int `json:"y"`
+	Duration int `json:"duration"`
+}
+
+// Renders a screen to a PNG sprite sheet. Identical consecutive
+// frames are stored once, with their durations added up. Optionally
+// pass filters for postprocessing each individual frame.
+//
+// Frames are laid out in a grid that's roughly square, to keep the
+// image within the size limits of most tools.
+func (s *Screens) EncodeSpriteSheet(maxDuration int, filters ...ImageFilter) (*SpriteSheet, error) {
+	frames, err := s.frames(maxDuration, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &SpriteSheet{Frames: []SpriteFrame{}}
+	if len(frames) == 0 {
+		return sheet, nil
+	}
+
+	bounds := frames[0].image.Bounds()
+	sheet.FrameWidth = bounds.Dx()
+	sheet.FrameHeight = bounds.Dy()
+
+	columns := int(math.Ceil(math.Sqrt(float64(len(frames)))))
+	rows := (len(frames) + columns - 1) / columns
+	sheet.Width = columns * sheet.FrameWidth
+	sheet.Height = rows * sheet.FrameHeight
+
+	im := image.NewNRGBA(image.Rect(0, 0, sheet.Width, sheet.Height))
+	for i, f := range frames {
+		if f.image.Bounds().Size() != bounds.Size() {
+			return nil, fmt.Errorf("frame %d is %v, expected %v", i, f.image.Bounds().Size(), bounds.Size())
+		}
+
+		pos := image.Pt(
+			(i%columns)*sheet.FrameWidth,
+			(i/columns)*sheet.FrameHeight,
+		)
+		draw.Draw(im, bounds.Sub(bounds.Min).Add(pos), f.image, f.image.Bounds().Min, draw.Src)
+
+		sheet.Frames = append(sheet.Frames, SpriteFrame{
+			X:        pos.X,
+			Y:        pos.Y,
+			Duration: f.duration,
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, im); err != nil {
+		return nil, fmt.Errorf("encoding: %w", err)
+	}
+	sheet.Image = buf.Bytes()
+
+	return sheet, nil
+}
@@ -0,0 +1,254 @@
+package encode
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"time"
+)
+
+// Pixel formats for FrameWriter.
+const (
+	// 3 bytes per pixel: R, G, B
+	PixelFormatRGB888 = "rgb888"
+
+	// 2 bytes per pixel, big endian, with 5 bits of red, 6 of
+	// green and 5 of blue
+	PixelFormatRGB565 = "rgb565"
+)
+
+// Protocols for FrameWriter.
+const (
+	// Each frame is a header followed by its pixels. The header
+	// is the magic "PXFR", the pixel format (uint8, 0 for RGB888
+	// and 1 for RGB565), width and height (uint16) and the
+	// frame's duration in milliseconds (uint32), all big endian.
+	StreamProtocolRaw = "raw"
+
+	// TPM2.net packets, as understood by many LED controllers.
+	// Frames are split into packets of at most
+	// TPM2MaxPacketData bytes. TPM2 only supports RGB888.
+	StreamProtocolTPM2 = "tpm2"
+
+	// Pixelflut text commands, one "PX x y rrggbb" line per
+	// pixel. Only pixels that changed since the previous frame
+	// are sent, in packets of at most PixelflutMaxPacketData
+	// bytes.
+	StreamProtocolPixelflut = "pixelflut"
+)
+
+// RawFrameMagic starts every frame written with StreamProtocolRaw.
+const RawFrameMagic = "PXFR"
+
+// TPM2MaxPacketData is the maximum number of pixel bytes sent in a
+// single TPM2.net packet.
+const TPM2MaxPacketData = 1488
+
+// PixelflutMaxPacketData is the maximum number of bytes of commands
+// sent in a single Pixelflut packet. It keeps packets within the MTU
+// of an Ethernet network.
+const PixelflutMaxPacketData = 1472
+
+// MinStreamFrameDuration is the shortest time a frame is shown for
+// when streaming. Frames with shorter durations are held this long.
+const MinStreamFrameDuration = 10 * time.Millisecond
+
+var pixelFormatIDs = map[string]byte{
+	PixelFormatRGB888: 0,
+	PixelFormatRGB565: 1,
+}
+
+// A FrameWriter writes uncompressed frames to w, for driving LED
+// matrices and other displays that can't decode images.
+//
+// Each frame is passed to w in as few calls to Write as the protocol
+// allows, so that w can be a UDP socket: TPM2 and Pixelflut make one
+// call per packet, and the raw protocol one per frame.
+type FrameWriter struct {
+	w           io.Writer
+	protocol    string
+	pixelFormat string
+	prev        []byte
+}
+
+// NewFrameWriter returns a FrameWriter for the given protocol and
+// pixel format.
+func NewFrameWriter(w io.Writer, protocol string, pixelFormat string) (*FrameWriter, error) {
+	switch protocol {
+	case StreamProtocolRaw, StreamProtocolTPM2, StreamProtocolPixelflut:
+	default:
+		return nil, fmt.Errorf("unknown protocol: %s", protocol)
+	}
+
+	if _, ok := pixelFormatIDs[pixelFormat]; !ok {
+		return nil, fmt.Errorf("unknown pixel format: %s", pixelFormat)
+	}
+
+	if pixelFormat != PixelFormatRGB888 && protocol != StreamProtocolRaw {
+		return nil, fmt.Errorf("protocol %s requires pixel format %s", protocol, PixelFormatRGB888)
+	}
+
+	return &FrameWriter{
+		w:           w,
+		protocol:    protocol,
+		pixelFormat: pixelFormat,
+	}, nil
+}
+
+// WriteFrame writes a single frame, which is to be displayed for
+// duration.
+func (fw *FrameWriter) WriteFrame(im image.Image, duration time.Duration) error {
+	var err error
+	switch fw.protocol {
+	case StreamProtocolTPM2:
+		err = fw.writeTPM2(im)
+	case StreamProtocolPixelflut:
+		err = fw.writePixelflut(im)
+	default:
+		err = fw.writeRaw(im, duration)
+	}
+	if err != nil {
+		return fmt.Errorf("writing frame: %w", err)
+	}
+
+	return nil
+}
+
+func (fw *FrameWriter) writeRaw(im image.Image, duration time.Duration) error {
+	b := im.Bounds()
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(RawFrameMagic)
+	buf.WriteByte(pixelFormatIDs[fw.pixelFormat])
+	binary.Write(buf, binary.BigEndian, uint16(b.Dx()))
+	binary.Write(buf, binary.BigEndian, uint16(b.Dy()))
+	binary.Write(buf, binary.BigEndian, uint32(duration.Milliseconds()))
+
+	pix := rgbPixels(im)
+	if fw.pixelFormat == PixelFormatRGB565 {
+		pix = rgb565(pix)
+	}
+	buf.Write(pix)
+
+	_, err := fw.w.Write(buf.Bytes())
+	return err
+}
+
+func (fw *FrameWriter) writeTPM2(im image.Image) error {
+	pix := rgbPixels(im)
+
+	packets := (len(pix) + TPM2MaxPacketData - 1) / TPM2MaxPacketData
+	if packets > 0xff {
+		return fmt.Errorf("frame too large for TPM2.net")
+	}
+
+	for i := 0; i < packets; i++ {
+		data := pix[i*TPM2MaxPacketData:]
+		if len(data) > TPM2MaxPacketData {
+			data = data[:TPM2MaxPacketData]
+		}
+
+		packet := make([]byte, 0, 7+len(data))
+		packet = append(packet, 0x9c, 0xda)
+		packet = binary.BigEndian.AppendUint16(packet, uint16(len(data)))
+		packet = append(packet, byte(i+1), byte(packets))
+		packet = append(packet, data...)
+		packet = append(packet, 0x36)
+
+		if _, err := fw.w.Write(packet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fw *FrameWriter) writePixelflut(im image.Image) error {
+	b := im.Bounds()
+	pix := rgbPixels(im)
+
+	// The previous frame is only useful if it's the same size
+	prev := fw.prev
+	if len(prev) != len(pix) {
+		prev = nil
+	}
+
+	// Commands are never split across packets
+	buf := &bytes.Buffer{}
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		_, err := fw.w.Write(buf.Bytes())
+		buf.Reset()
+		return err
+	}
+
+	for i := 0; i < len(pix); i += 3 {
+		if prev != nil && bytes.Equal(pix[i:i+3], prev[i:i+3]) {
+			continue
+		}
+		x, y := (i/3)%b.Dx(), (i/3)/b.Dx()
+		line := fmt.Sprintf("PX %d %d %02x%02x%02x\n", x, y, pix[i], pix[i+1], pix[i+2])
+
+		if buf.Len()+len(line) > PixelflutMaxPacketData {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		buf.WriteString(line)
+	}
+	fw.prev = pix
+
+	return flush()
+}
+
+// Converts RGB triplets to big endian RGB565.
+func rgb565(pix []byte) []byte {
+	out := make([]byte, 0, len(pix)/3*2)
+	for i := 0; i < len(pix); i += 3 {
+		c := uint16(pix[i]>>3)<<11 | uint16(pix[i+1]>>2)<<5 | uint16(pix[i+2]>>3)
+		out = binary.BigEndian.AppendUint16(out, c)
+	}
+	return out
+}
+
+// Stream writes the frames of a screen to fw over and over, waiting
+// for each frame's duration, but at least MinStreamFrameDuration,
+// before writing the next. Frames are rendered and filtered once, and
+// identical consecutive frames are written once. Returns nil when ctx
+// is done, or the first error writing a frame.
+func (s *Screens) Stream(ctx context.Context, fw *FrameWriter, maxDuration int, filters ...ImageFilter) error {
+	frames, err := s.frames(maxDuration, filters...)
+	if err != nil {
+		return err
+	}
+
+	if len(frames) == 0 {
+		// nothing to show
+		<-ctx.Done()
+		return nil
+	}
+
+	for {
+		for _, f := range frames {
+			duration := time.Duration(f.duration) * time.Millisecond
+			if duration < MinStreamFrameDuration {
+				duration = MinStreamFrameDuration
+			}
+
+			if err := fw.WriteFrame(f.image, duration); err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(duration):
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package encode
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tidbyt.dev/pixlet/render"
+)
+
+// Records each call to Write separately, like a UDP socket would.
+type packetWriter struct {
+	packets [][]byte
+}
+
+func (w *packetWriter) Write(p []byte) (int, error) {
+	w.packets = append(w.packets, append([]byte{}, p...))
+	return len(p), nil
+}
+
+func testFrame(w, h int, c color.RGBA) *image.RGBA {
+	im := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < w*h; i++ {
+		im.SetRGBA(i%w, i/w, c)
+	}
+	return im
+}
+
+func TestNewFrameWriter(t *testing.T) {
+	_, err := NewFrameWriter(&bytes.Buffer{}, "foo", PixelFormatRGB888)
+	assert.Error(t, err)
+
+	_, err = NewFrameWriter(&bytes.Buffer{}, StreamProtocolRaw, "rgb444")
+	assert.Error(t, err)
+
+	_, err = NewFrameWriter(&bytes.Buffer{}, StreamProtocolTPM2, PixelFormatRGB565)
+	assert.Error(t, err)
+
+	_, err = NewFrameWriter(&bytes.Buffer{}, StreamProtocolRaw, PixelFormatRGB565)
+	assert.NoError(t, err)
+}
+
+func TestFrameWriterRaw(t *testing.T) {
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolRaw, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	im := testFrame(2, 1, color.RGBA{0x12, 0x34, 0x56, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 300*time.Millisecond))
+
+	require.Equal(t, 1, len(w.packets))
+	assert.Equal(t, []byte(RawFrameMagic), w.packets[0][0:4])
+	assert.Equal(t, []byte{
+		0,    // pixel format
+		0, 2, // width
+		0, 1, // height
+		0, 0, 0x01, 0x2c, // duration
+		0x12, 0x34, 0x56,
+		0x12, 0x34, 0x56,
+	}, w.packets[0][4:])
+
+	w = &packetWriter{}
+	fw, err = NewFrameWriter(w, StreamProtocolRaw, PixelFormatRGB565)
+	require.NoError(t, err)
+
+	im = testFrame(2, 1, color.RGBA{0xff, 0, 0, 0xff})
+	im.SetRGBA(1, 0, color.RGBA{0, 0xff, 0xff, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+
+	require.Equal(t, 1, len(w.packets))
+	assert.Equal(t, byte(1), w.packets[0][4])
+	assert.Equal(t, []byte{0xf8, 0x00, 0x07, 0xff}, w.packets[0][13:])
+}
+
+func TestFrameWriterTPM2(t *testing.T) {
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolTPM2, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	// 64x32 RGB is 6144 bytes, which takes 5 packets
+	im := testFrame(64, 32, color.RGBA{1, 2, 3, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+	require.Equal(t, 5, len(w.packets))
+
+	total := 0
+	for i, p := range w.packets {
+		size := int(p[2])<<8 | int(p[3])
+		assert.Equal(t, []byte{0x9c, 0xda}, p[0:2])
+		assert.Equal(t, byte(i+1), p[4])
+		assert.Equal(t, byte(5), p[5])
+		assert.Equal(t, size+7, len(p))
+		assert.Equal(t, byte(0x36), p[len(p)-1])
+		assert.Equal(t, []byte{1, 2, 3}, p[6:9])
+		total += size
+	}
+	assert.Equal(t, 64*32*3, total)
+}
+
+func TestFrameWriterPixelflut(t *testing.T) {
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolPixelflut, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	im := testFrame(2, 2, color.RGBA{0, 0, 0, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+	require.Equal(t, 1, len(w.packets))
+	assert.Equal(t, "PX 0 0 000000\nPX 1 0 000000\nPX 0 1 000000\nPX 1 1 000000\n", string(w.packets[0]))
+
+	// Only changed pixels are sent
+	im = testFrame(2, 2, color.RGBA{0, 0, 0, 0xff})
+	im.SetRGBA(1, 1, color.RGBA{0xff, 0x80, 0, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+	require.Equal(t, 2, len(w.packets))
+	assert.Equal(t, "PX 1 1 ff8000\n", string(w.packets[1]))
+
+	// Nothing at all for an identical frame
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+	assert.Equal(t, 2, len(w.packets))
+}
+
+func TestFrameWriterPixelflutPackets(t *testing.T) {
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolPixelflut, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	// A whole frame doesn't fit in one packet, so it's split
+	// between commands
+	im := testFrame(128, 64, color.RGBA{0xff, 0, 0, 0xff})
+	require.NoError(t, fw.WriteFrame(im, 50*time.Millisecond))
+	require.Greater(t, len(w.packets), 1)
+
+	lines := 0
+	for _, p := range w.packets {
+		assert.LessOrEqual(t, len(p), PixelflutMaxPacketData)
+		assert.True(t, bytes.HasSuffix(p, []byte("\n")))
+		lines += bytes.Count(p, []byte("\n"))
+	}
+	assert.Equal(t, 128*64, lines)
+}
+
+func TestStream(t *testing.T) {
+	roots := []render.Root{{
+		Delay: 20,
+		Child: render.Animation{Children: []render.Widget{
+			render.Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}},
+			render.Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}},
+			render.Box{Width: 1, Height: 1, Color: color.RGBA{0, 0xff, 0, 0xff}},
+		}},
+	}}
+
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolRaw, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	// Frames are written with their durations, paced by them, and
+	// looped until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.NoError(t, ScreensFromRoots(roots).Stream(ctx, fw, 0))
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	require.Equal(t, 3, len(w.packets))
+	assert.Equal(t, []byte{0, 0, 0, 40}, w.packets[0][9:13])
+	assert.Equal(t, []byte{0, 0, 0, 20}, w.packets[1][9:13])
+	assert.Equal(t, []byte{0, 0, 0, 40}, w.packets[2][9:13])
+
+	// Streaming stops when the context is done
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	w.packets = nil
+	require.NoError(t, ScreensFromRoots(roots).Stream(ctx, fw, 0))
+	assert.Equal(t, 1, len(w.packets))
+}
+
+func TestStreamMinFrameDuration(t *testing.T) {
+	roots := []render.Root{{
+		Child: render.Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}},
+	}}
+
+	w := &packetWriter{}
+	fw, err := NewFrameWriter(w, StreamProtocolRaw, PixelFormatRGB888)
+	require.NoError(t, err)
+
+	// Frames cut down to next to no duration don't flood the stream
+	ctx, cancel := context.WithTimeout(context.Background(), 5*MinStreamFrameDuration)
+	defer cancel()
+	require.NoError(t, ScreensFromRoots(roots).Stream(ctx, fw, 1))
+	assert.LessOrEqual(t, len(w.packets), 6)
+	assert.Equal(t, []byte{0, 0, 0, 10}, w.packets[0][9:13])
+}
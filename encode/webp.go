@@ -12,16 +12,21 @@ import (
 // Renders a screen to WebP. Optionally pass filters for
 // postprocessing each individual frame.
 func (s *Screens) EncodeWebP(maxDuration int, filters ...ImageFilter) ([]byte, error) {
-	images, err := s.render(filters...)
+	frames, err := s.frames(maxDuration, filters...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(images) == 0 {
+	if len(frames) == 0 {
 		return []byte{}, nil
 	}
 
-	bounds := images[0].Bounds()
+	return encodeWebPFrames(frames)
+}
+
+// encodeWebPFrames encodes frames to an animated WebP.
+func encodeWebPFrames(frames []frame) ([]byte, error) {
+	bounds := frames[0].image.Bounds()
 	anim, err := webp.NewAnimationEncoder(
 		bounds.Dx(),
 		bounds.Dy(),
@@ -33,24 +38,11 @@ func (s *Screens) EncodeWebP(maxDuration int, filters ...ImageFilter) ([]byte, e
 	}
 	defer anim.Close()
 
-	remainingDuration := time.Duration(maxDuration) * time.Millisecond
-	for _, im := range images {
-		frameDuration := time.Duration(s.delay) * time.Millisecond
-
-		if maxDuration > 0 {
-			if frameDuration > remainingDuration {
-				frameDuration = remainingDuration
-			}
-			remainingDuration -= frameDuration
-		}
-
-		if err := anim.AddFrame(im, frameDuration); err != nil {
+	for _, f := range frames {
+		frameDuration := time.Duration(f.duration) * time.Millisecond
+		if err := anim.AddFrame(f.image, frameDuration); err != nil {
 			return nil, fmt.Errorf("%s: %w", "adding frame", err)
 		}
-
-		if maxDuration > 0 && remainingDuration <= 0 {
-			break
-		}
 	}
 
 	buf, err := anim.Assemble()
@@ -0,0 +1,355 @@
+// Package playlist provides structures and primitives to render several
+// apps one after the other, the way a device cycles through its apps.
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tidbyt/gg"
+	"gopkg.in/yaml.v3"
+
+	"tidbyt.dev/pixlet/render"
+	"tidbyt.dev/pixlet/render/animation"
+	"tidbyt.dev/pixlet/runtime"
+	"tidbyt.dev/pixlet/tools"
+)
+
+// DefaultDwell is how long each app is shown, in milliseconds, unless
+// the playlist says otherwise.
+const DefaultDwell = 15000
+
+// Playlist is a list of apps to show in turn.
+//
+// A playlist is usually loaded from a YAML file:
+//
+//	dwell: 10000
+//	transition:
+//	  effect: push
+//	apps:
+//	  - path: clock
+//	    config:
+//	      timezone: Europe/Stockholm
+//	  - path: weather/weather.star
+//	    dwell: 5000
+//	    transition:
+//	      effect: fade
+//
+// Paths are relative to the playlist file.
+type Playlist struct {
+	// Dwell is how long each app is shown, in milliseconds. Apps
+	// with animations that are shorter loop until their time is
+	// up. Apps that are longer are cut off, unless their root
+	// sets ShowFullAnimation.
+	Dwell int `json:"dwell,omitempty" yaml:"dwell,omitempty"`
+
+	// Transition leads into each app from the one before it. The
+	// first app is shown without one.
+	Transition *Transition `json:"transition,omitempty" yaml:"transition,omitempty"`
+
+	// Apps to show, in order.
+	Apps []Entry `json:"apps" yaml:"apps"`
+
+	// Dir is the directory app paths are relative to.
+	Dir string `json:"-" yaml:"-"`
+}
+
+// Entry is a single app in a playlist.
+type Entry struct {
+	// Path is the app's .star file, or its directory.
+	Path string `json:"path" yaml:"path"`
+
+	// Config is passed to the app's main function.
+	Config map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+
+	// Dwell overrides the playlist's dwell for this app.
+	Dwell int `json:"dwell,omitempty" yaml:"dwell,omitempty"`
+
+	// Transition overrides the playlist's transition into this app.
+	Transition *Transition `json:"transition,omitempty" yaml:"transition,omitempty"`
+}
+
+// Transition is an effect going from one app to the next. Its fields
+// are those of `animation.Transition`, with the duration in frames of
+// the app being transitioned to. Transitions play before the app's
+// dwell time starts.
+type Transition struct {
+	Effect    string `json:"effect" yaml:"effect"`
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+	Duration  int    `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Curve     string `json:"curve,omitempty" yaml:"curve,omitempty"`
+}
+
+// Returns the transition as painted by roots.
+func (t *Transition) render() (render.Transition, error) {
+	tr := &animation.Transition{
+		Effect:    t.Effect,
+		Direction: t.Direction,
+		Duration:  t.Duration,
+	}
+
+	if t.Curve != "" {
+		curve, err := animation.ParseCurve(t.Curve)
+		if err != nil {
+			return nil, err
+		}
+		tr.Curve = curve
+	}
+
+	if err := tr.Init(); err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+// LoadPlaylist reads a playlist from an io.Reader. App paths are
+// taken to be relative to dir.
+func LoadPlaylist(r io.Reader, dir string) (*Playlist, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read playlist: %w", err)
+	}
+
+	p := &Playlist{}
+	err = yaml.Unmarshal(b, p)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal playlist: %w", err)
+	}
+	p.Dir = dir
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// LoadPlaylistFile reads a playlist from a file.
+func LoadPlaylistFile(path string) (*Playlist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open playlist: %w", err)
+	}
+	defer f.Close()
+
+	return LoadPlaylist(f, filepath.Dir(path))
+}
+
+// Validate ensures the playlist has apps, and that dwell times make
+// sense.
+func (p *Playlist) Validate() error {
+	if len(p.Apps) == 0 {
+		return fmt.Errorf("playlist has no apps")
+	}
+
+	if p.Dwell < 0 {
+		return fmt.Errorf("dwell must not be negative")
+	}
+
+	if p.Transition != nil {
+		if _, err := p.Transition.render(); err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+	}
+
+	for i, e := range p.Apps {
+		if e.Path == "" {
+			return fmt.Errorf("app %d has no path", i)
+		}
+		if e.Dwell < 0 {
+			return fmt.Errorf("app %d: dwell must not be negative", i)
+		}
+		if e.Transition != nil {
+			if _, err := e.Transition.render(); err != nil {
+				return fmt.Errorf("app %d: transition: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Paths returns the path of every app in the playlist.
+func (p *Playlist) Paths() []string {
+	paths := make([]string, len(p.Apps))
+	for i, e := range p.Apps {
+		paths[i] = filepath.Join(p.Dir, e.Path)
+	}
+	return paths
+}
+
+// Roots runs every app in the playlist, and returns their roots in
+// order. An app's dwell time is split between its roots, in proportion
+// to how long their animations are. Each root is looped or cut off to
+// fill its share, and
+// the first root of each app but the first gets the transition into
+// it, so that encoding the roots gives the whole playlist as one
+// animation.
+//
+// Each app is run with a context derived from ctx, and given timeout
+// to run.
+func (p *Playlist) Roots(ctx context.Context, timeout time.Duration, opts ...runtime.AppletOption) ([]render.Root, error) {
+	var roots []render.Root
+
+	for i, e := range p.Apps {
+		path := filepath.Join(p.Dir, e.Path)
+
+		applet, err := loadApplet(path, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("app %d (%s): %w", i, e.Path, err)
+		}
+
+		appCtx := ctx
+		cancel := func() {}
+		if timeout > 0 {
+			appCtx, cancel = context.WithTimeoutCause(
+				ctx,
+				timeout,
+				fmt.Errorf("timeout after %s", timeout),
+			)
+		}
+		appRoots, err := applet.RunWithConfig(appCtx, e.Config)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("app %d (%s): %w", i, e.Path, err)
+		}
+
+		dwell := p.Dwell
+		if e.Dwell > 0 {
+			dwell = e.Dwell
+		}
+		if dwell == 0 {
+			dwell = DefaultDwell
+		}
+
+		transition := p.Transition
+		if e.Transition != nil {
+			transition = e.Transition
+		}
+		if transition != nil && i > 0 && len(appRoots) > 0 {
+			tr, err := transition.render()
+			if err != nil {
+				return nil, fmt.Errorf("app %d (%s): %w", i, e.Path, err)
+			}
+			appRoots[0].Transition = tr
+		}
+
+		for j, d := range splitDwell(appRoots, dwell) {
+			roots = append(roots, withDwell(appRoots[j], d))
+		}
+	}
+
+	return roots, nil
+}
+
+// Loads the app at path, which is either a .star file or a
+// directory.
+func loadApplet(path string, opts ...runtime.AppletOption) (*runtime.Applet, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	var fsys fs.FS
+	if info.IsDir() {
+		fsys = os.DirFS(path)
+	} else {
+		if !strings.HasSuffix(path, ".star") {
+			return nil, fmt.Errorf("script file must have suffix .star: %s", path)
+		}
+		fsys = tools.NewSingleFileFS(path)
+	}
+
+	applet, err := runtime.NewAppletFromFS(filepath.Base(path), fsys, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applet: %w", err)
+	}
+
+	return applet, nil
+}
+
+// Splits dwell milliseconds between roots, in proportion to how long
+// each one's animation runs.
+func splitDwell(roots []render.Root, dwell int) []int {
+	durations := make([]int, len(roots))
+	total := 0
+	for i, r := range roots {
+		frames := r.Child.FrameCount()
+		if frames < 1 {
+			frames = 1
+		}
+		durations[i] = frames * rootDelay(r)
+		total += durations[i]
+	}
+
+	shares := make([]int, len(roots))
+	for i, d := range durations {
+		shares[i] = dwell * d / total
+	}
+	return shares
+}
+
+// Returns the delay of a root's frames in milliseconds.
+func rootDelay(r render.Root) int {
+	if r.Delay <= 0 {
+		return render.DefaultDelay
+	}
+	return int(r.Delay)
+}
+
+// Makes a root last for dwell milliseconds, by looping or cutting
+// off its animation.
+func withDwell(r render.Root, dwell int) render.Root {
+	delay := rootDelay(r)
+
+	frames := dwell / delay
+	if frames < 1 {
+		frames = 1
+	}
+	if r.ShowFullAnimation && r.Child.FrameCount() > frames {
+		frames = r.Child.FrameCount()
+	}
+
+	// Every root gets an explicit delay, since roots without one
+	// would otherwise get the delay of the first root.
+	r.Delay = int32(delay)
+	r.Child = loop{Child: r.Child, Frames: frames}
+
+	return r
+}
+
+// loop repeats its child's animation for a fixed number of frames.
+type loop struct {
+	render.Widget
+
+	Child  render.Widget
+	Frames int
+}
+
+func (l loop) childFrame(frameIdx int) int {
+	count := l.Child.FrameCount()
+	if count < 1 {
+		return 0
+	}
+	return frameIdx % count
+}
+
+func (l loop) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return l.Child.PaintBounds(bounds, l.childFrame(frameIdx))
+}
+
+func (l loop) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	l.Child.Paint(dc, bounds, l.childFrame(frameIdx))
+}
+
+func (l loop) FrameCount() int {
+	return l.Frames
+}
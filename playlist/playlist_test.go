@@ -0,0 +1,202 @@
+package playlist_test
+
+import (
+	"context"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tidbyt.dev/pixlet/encode"
+	"tidbyt.dev/pixlet/playlist"
+	"tidbyt.dev/pixlet/render"
+	"tidbyt.dev/pixlet/render/animation"
+)
+
+func TestLoadPlaylist(t *testing.T) {
+	p, err := playlist.LoadPlaylistFile(filepath.Join("testdata", "playlist.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1000, p.Dwell)
+	assert.Equal(t, []playlist.Entry{
+		{Path: "red"},
+		{Path: "blue/blue.star", Dwell: 500, Config: map[string]string{"shade": "dark"}},
+	}, p.Apps)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "red"),
+		filepath.Join("testdata", "blue", "blue.star"),
+	}, p.Paths())
+}
+
+func TestLoadPlaylistInvalid(t *testing.T) {
+	for _, src := range []string{
+		"apps: []",
+		"dwell: -1\napps:\n  - path: foo",
+		"apps:\n  - config: {}",
+		"apps:\n  - path: foo\n    dwell: -1",
+		"transition:\n  effect: explode\napps:\n  - path: foo",
+		"apps:\n  - path: foo\n    transition:\n      effect: push\n      direction: sideways",
+		"apps:\n  - path: foo\n    transition:\n      effect: fade\n      curve: wobbly",
+		"apps: {",
+	} {
+		_, err := playlist.LoadPlaylist(strings.NewReader(src), ".")
+		assert.Error(t, err, src)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	p, err := playlist.LoadPlaylistFile(filepath.Join("testdata", "playlist.yaml"))
+	require.NoError(t, err)
+
+	roots, err := p.Roots(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(roots))
+
+	// The red app's 2 frame animation loops for 1000 ms
+	assert.Equal(t, int32(100), roots[0].Delay)
+	frames := roots[0].Paint(true)
+	require.Equal(t, 10, len(frames))
+	for i, im := range frames {
+		expected := color.RGBA{0xff, 0, 0, 0xff}
+		if i%2 == 1 {
+			expected = color.RGBA{0x88, 0, 0, 0xff}
+		}
+		assert.Equal(t, expected, im.At(0, 0), "frame %d", i)
+	}
+
+	// The blue app gets its config, and the default delay
+	assert.Equal(t, int32(render.DefaultDelay), roots[1].Delay)
+	frames = roots[1].Paint(true)
+	assert.Equal(t, 500/render.DefaultDelay, len(frames))
+	assert.Equal(t, color.RGBA{0, 0, 0x88, 0xff}, frames[0].At(0, 0))
+}
+
+func TestRootsShowFullAnimation(t *testing.T) {
+	dir := t.TempDir()
+	for _, full := range []string{"True", "False"} {
+		src := `
+load("render.star", "render")
+
+def main():
+    return render.Root(
+        delay = 100,
+        show_full_animation = ` + full + `,
+        child = render.Animation(
+            children = [render.Box(width = i + 1) for i in range(8)],
+        ),
+    )
+`
+		require.NoError(t, os.Mkdir(filepath.Join(dir, full), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, full, "app.star"), []byte(src), 0644))
+	}
+
+	p, err := playlist.LoadPlaylist(strings.NewReader(`
+dwell: 300
+apps:
+  - path: True
+  - path: False
+`), dir)
+	require.NoError(t, err)
+
+	roots, err := p.Roots(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(roots))
+
+	// Animations longer than the dwell are only shown in full if
+	// they ask for it
+	assert.Equal(t, 8, roots[0].Child.FrameCount())
+	assert.Equal(t, 3, roots[1].Child.FrameCount())
+}
+
+func TestRootsMultipleRoots(t *testing.T) {
+	dir := t.TempDir()
+	src := `
+load("render.star", "render")
+
+def main():
+    return [
+        render.Root(delay = 100, child = render.Animation(children = [render.Box(), render.Box()])),
+        render.Root(delay = 100, child = render.Box()),
+        render.Root(delay = 50, child = render.Box()),
+    ]
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.star"), []byte(src), 0644))
+
+	p, err := playlist.LoadPlaylist(strings.NewReader(`
+dwell: 700
+apps:
+  - path: app.star
+`), dir)
+	require.NoError(t, err)
+
+	roots, err := p.Roots(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(roots))
+
+	// The app's dwell is split between its roots, in proportion to
+	// how long their animations run
+	var frames []int
+	total := 0
+	for _, r := range roots {
+		frames = append(frames, r.Child.FrameCount())
+		total += r.Child.FrameCount() * int(r.Delay)
+	}
+	assert.Equal(t, []int{4, 2, 2}, frames)
+	assert.Equal(t, 700, total)
+}
+
+func TestRootsTransition(t *testing.T) {
+	p, err := playlist.LoadPlaylist(strings.NewReader(`
+dwell: 1000
+transition:
+  effect: fade
+  duration: 4
+apps:
+  - path: red
+  - path: blue/blue.star
+    dwell: 500
+  - path: red
+    transition:
+      effect: push
+      direction: up
+      curve: ease_in
+`), "testdata")
+	require.NoError(t, err)
+
+	roots, err := p.Roots(context.Background(), time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(roots))
+
+	// There's nothing to transition from into the first app
+	assert.Nil(t, roots[0].Transition)
+
+	fade, ok := roots[1].Transition.(*animation.Transition)
+	require.True(t, ok)
+	assert.Equal(t, animation.TransitionFade, fade.Effect)
+	assert.Equal(t, 4, fade.Duration)
+
+	push, ok := roots[2].Transition.(*animation.Transition)
+	require.True(t, ok)
+	assert.Equal(t, animation.TransitionPush, push.Effect)
+	assert.Equal(t, "up", push.Direction)
+	assert.Equal(t, animation.DefaultTransitionDuration, push.Duration)
+
+	// Transitions play on top of each app's dwell time
+	screens := encode.ScreensFromRoots(roots)
+	_, err = screens.EncodeGIF(0)
+	require.NoError(t, err)
+	assert.Equal(t, 10+4+500/render.DefaultDelay+animation.DefaultTransitionDuration+10, screens.FrameCount())
+}
+
+func TestRootsMissingApp(t *testing.T) {
+	p, err := playlist.LoadPlaylist(strings.NewReader("apps:\n  - path: nope.star"), t.TempDir())
+	require.NoError(t, err)
+
+	_, err = p.Roots(context.Background(), time.Second)
+	assert.Error(t, err)
+}
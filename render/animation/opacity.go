@@ -0,0 +1,45 @@
+package animation
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Transform by changing the opacity of the child, from `0.0` (fully
+// transparent) to `1.0` (fully opaque).
+//
+// DOC(Value): Opacity of the child
+type Opacity struct {
+	Value float64 `starlark:"value,required"`
+}
+
+func (self Opacity) Apply(ctx *gg.Context, origin Vec2f, rounding Rounding) {
+	// Opacity is applied when compositing the child, see recolor.
+}
+
+func (self Opacity) Interpolate(other Transform, progress float64) (result Transform, ok bool) {
+	if other, ok := other.(Opacity); ok {
+		return Opacity{Lerp(self.Value, other.Value, progress)}, true
+	}
+
+	return OpacityDefault, false
+}
+
+func (self Opacity) isIdentity() bool {
+	return self.Value >= 1.0
+}
+
+func (self Opacity) recolor(c color.RGBA) color.RGBA {
+	f := math.Max(0.0, math.Min(1.0, self.Value))
+
+	return color.RGBA{
+		uint8(math.Round(float64(c.R) * f)),
+		uint8(math.Round(float64(c.G) * f)),
+		uint8(math.Round(float64(c.B) * f)),
+		uint8(math.Round(float64(c.A) * f)),
+	}
+}
+
+var OpacityDefault = Opacity{1.0}
@@ -0,0 +1,42 @@
+package animation
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertInterpolateOpacity(
+	t *testing.T,
+	expected float64,
+	from float64,
+	to float64,
+	progress float64,
+) {
+	AssertInterpolate(t, Opacity{Value: expected}, Opacity{Value: from}, Opacity{Value: to}, progress)
+}
+
+func TestInterpolateOpacity(t *testing.T) {
+	from := 0.0
+	to := 1.0
+
+	assertInterpolateOpacity(t, 0.0, from, to, 0.0)
+	assertInterpolateOpacity(t, 0.25, from, to, 0.25)
+	assertInterpolateOpacity(t, 0.5, from, to, 0.5)
+	assertInterpolateOpacity(t, 1.0, from, to, 1.0)
+}
+
+func TestOpacityRecolor(t *testing.T) {
+	assert.Equal(t,
+		color.RGBA{0x80, 0x40, 0, 0x80},
+		Opacity{0.5}.recolor(color.RGBA{0xff, 0x80, 0, 0xff}))
+
+	// Out of range values are clamped
+	assert.Equal(t,
+		color.RGBA{0xff, 0x80, 0, 0xff},
+		Opacity{2.0}.recolor(color.RGBA{0xff, 0x80, 0, 0xff}))
+	assert.Equal(t,
+		color.RGBA{},
+		Opacity{-1.0}.recolor(color.RGBA{0xff, 0x80, 0, 0xff}))
+}
@@ -0,0 +1,71 @@
+package animation
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Transform by tinting the child with a color.
+//
+// The alpha of the color sets the strength of the tint: `#f00` turns
+// the child entirely red, while `#f008` mixes in about half red. The
+// child's own transparency is kept.
+//
+// DOC(Color): Color to tint with
+type Tint struct {
+	Color color.Color `starlark:"color,required"`
+}
+
+func (self Tint) Apply(ctx *gg.Context, origin Vec2f, rounding Rounding) {
+	// Tints are applied when compositing the child, see recolor.
+}
+
+// Tints are interpolated with premultiplied alpha, so that fading in
+// a tint from none keeps its color.
+func (self Tint) Interpolate(other Transform, progress float64) (result Transform, ok bool) {
+	if other, ok := other.(Tint); ok {
+		from, to := self.rgba(), other.rgba()
+		lerp := func(a, b uint8) uint8 {
+			return uint8(math.Round(Lerp(float64(a), float64(b), progress)))
+		}
+
+		return Tint{color.RGBA{
+			lerp(from.R, to.R),
+			lerp(from.G, to.G),
+			lerp(from.B, to.B),
+			lerp(from.A, to.A),
+		}}, true
+	}
+
+	return TintDefault, false
+}
+
+func (self Tint) rgba() color.RGBA {
+	if self.Color == nil {
+		return color.RGBA{}
+	}
+
+	return color.RGBAModel.Convert(self.Color).(color.RGBA)
+}
+
+func (self Tint) isIdentity() bool {
+	return self.rgba().A == 0
+}
+
+func (self Tint) recolor(c color.RGBA) color.RGBA {
+	t := self.rgba()
+	strength := float64(t.A) / 0xff
+	alpha := float64(c.A) / 0xff
+
+	// The tint is premultiplied by its own alpha, and is then
+	// painted with the alpha of the child.
+	mix := func(v, tv uint8) uint8 {
+		return uint8(math.Round(float64(v)*(1-strength) + float64(tv)*alpha))
+	}
+
+	return color.RGBA{mix(c.R, t.R), mix(c.G, t.G), mix(c.B, t.B), c.A}
+}
+
+var TintDefault = Tint{color.RGBA{}}
@@ -0,0 +1,35 @@
+package animation
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolateTint(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	AssertInterpolate(t, Tint{red}, Tint{red}, Tint{blue}, 0.0)
+	AssertInterpolate(t, Tint{color.RGBA{0x80, 0, 0x80, 0xff}}, Tint{red}, Tint{blue}, 0.5)
+	AssertInterpolate(t, Tint{blue}, Tint{red}, Tint{blue}, 1.0)
+
+	// Fading in from no tint keeps the color of the tint
+	AssertInterpolate(t, Tint{color.RGBA{0x80, 0, 0, 0x80}}, TintDefault, Tint{red}, 0.5)
+}
+
+func TestTintRecolor(t *testing.T) {
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, Tint{color.RGBA{0xff, 0, 0, 0xff}}.recolor(white))
+	assert.Equal(t, color.RGBA{0xff, 0x80, 0x80, 0xff}, Tint{color.NRGBA{0xff, 0, 0, 0x7f}}.recolor(white))
+
+	// The child's transparency is kept
+	assert.Equal(t,
+		color.RGBA{0, 0x80, 0, 0x80},
+		Tint{color.RGBA{0, 0xff, 0, 0xff}}.recolor(color.RGBA{0x80, 0x80, 0x80, 0x80}))
+
+	assert.True(t, TintDefault.isIdentity())
+	assert.True(t, Tint{}.isIdentity())
+}
@@ -1,6 +1,8 @@
 package animation
 
 import (
+	"image/color"
+
 	"github.com/tidbyt/gg"
 )
 
@@ -9,6 +11,19 @@ type Transform interface {
 	Interpolate(other Transform, progress float64) (result Transform, ok bool)
 }
 
+// Transforms that change the colors of the child, rather than where
+// it's painted. They're applied to every pixel of the child, which is
+// then composited with alpha.
+type colorTransform interface {
+	Transform
+
+	// Whether the transform leaves colors as they are.
+	isIdentity() bool
+
+	// Returns the transformed color. Colors are premultiplied by alpha.
+	recolor(c color.RGBA) color.RGBA
+}
+
 func ExtendTransforms(lhs []Transform, rhs []Transform) []Transform {
 	for i, transform := range rhs {
 		if i >= len(lhs) {
@@ -19,6 +34,10 @@ func ExtendTransforms(lhs []Transform, rhs []Transform) []Transform {
 				lhs = append(lhs, ScaleDefault)
 			case Rotate:
 				lhs = append(lhs, RotateDefault)
+			case Opacity:
+				lhs = append(lhs, OpacityDefault)
+			case Tint:
+				lhs = append(lhs, TintDefault)
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package animation
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"sort"
 
 	"github.com/tidbyt/gg"
@@ -75,10 +76,33 @@ func findKeyframes(arr []Keyframe, p float64) (Keyframe, Keyframe, error) {
 		fmt.Errorf("failed to find adjacent keyframes for percentage: %f (this should be unreachable!?)", p)
 }
 
+// Interpolate the transforms of keyframes at the given progress.
+func transformsAt(keyframes []Keyframe, progress float64) []Transform {
+	// Find the adjacent keyframes to interpolate between.
+	from, to, err := findKeyframes(keyframes, progress)
+	if err != nil {
+		return nil
+	}
+
+	// Rescale animation progress to progress between keyframes and apply easing curve.
+	progress = Rescale(from.Percentage.Value, to.Percentage.Value, 0.0, 1.0, progress)
+	progress = from.Curve.Transform(progress)
+
+	transforms, ok := InterpolateTransforms(from.Transforms, to.Transforms, progress)
+	if !ok {
+		return nil
+	}
+
+	return transforms
+}
+
 // Transformation makes it possible to animate a child widget by
 // transitioning between transforms which are applied to the child wiget.
 //
-// It supports animating translation, scale and rotation of its child.
+// It supports animating translation, scale and rotation of its child,
+// as well as its opacity and a color tint. Opacity and tints are
+// applied to the child as a whole, which is then composited with
+// alpha onto whatever is below it.
 //
 // If you have used CSS transforms and animations before, some of the
 // following concepts will be familiar to you.
@@ -225,21 +249,30 @@ func (self *Transformation) Paint(dc *gg.Context, bounds image.Rectangle, frameI
 
 	dc.Push()
 
-	// Find the adjacent keyframes to interpolate between.
-	if from, to, err := findKeyframes(self.Keyframes, progress); err == nil {
-		// Rescale animation progress to progress between keyframes and apply easing curve.
-		progress = Rescale(from.Percentage.Value, to.Percentage.Value, 0.0, 1.0, progress)
-		progress = from.Curve.Transform(progress)
-
-		// Interpolate between transforms and apply them in order.
-		if transforms, ok := InterpolateTransforms(from.Transforms, to.Transforms, progress); ok {
-			for _, transform := range transforms {
-				transform.Apply(dc, origin, self.Rounding)
-			}
+	// Apply the interpolated transforms in order. Color transforms
+	// are kept for when the child is painted.
+	var colorTransforms []colorTransform
+	for _, transform := range transformsAt(self.Keyframes, progress) {
+		transform.Apply(dc, origin, self.Rounding)
+
+		if ct, ok := transform.(colorTransform); ok && !ct.isIdentity() {
+			colorTransforms = append(colorTransforms, ct)
 		}
 	}
 
-	self.Child.Paint(dc, bounds, frameIdx)
+	if len(colorTransforms) == 0 {
+		self.Child.Paint(dc, bounds, frameIdx)
+	} else {
+		recolor := func(c color.RGBA) color.RGBA {
+			for _, ct := range colorTransforms {
+				c = ct.recolor(c)
+			}
+			return c
+		}
+		render.PaintLayer(dc, recolor, func(dc *gg.Context) {
+			self.Child.Paint(dc, bounds, frameIdx)
+		})
+	}
 
 	dc.Pop()
 }
@@ -410,3 +410,41 @@ func TestTransformationAll(t *testing.T) {
 		"..⁘◎○.░▒⎕",
 	}, im))
 }
+
+func TestTransformationOpacityAndTint(t *testing.T) {
+	o := Transformation{
+		Child: render.Box{Width: 2, Height: 1, Color: color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		Keyframes: []Keyframe{
+			{
+				Percentage: Percentage{0.0},
+				Curve:      LinearCurve{},
+				Transforms: []Transform{Opacity{0.0}},
+			},
+			{
+				Percentage: Percentage{1.0},
+				Curve:      LinearCurve{},
+				Transforms: []Transform{Opacity{1.0}, Tint{color.RGBA{0xff, 0, 0, 0xff}}},
+			},
+		},
+		Duration:  3,
+		Width:     2,
+		Height:    1,
+		Origin:    DefaultOrigin,
+		Direction: DefaultDirection,
+		FillMode:  DefaultFillMode,
+		Rounding:  DefaultRounding,
+	}
+	assert.NoError(t, o.Init())
+
+	// Fully transparent
+	im := render.PaintWidget(&o, image.Rect(0, 0, 2, 1), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{}, im.RGBAAt(0, 0))
+
+	// Half way, the tint is half way in as well
+	im = render.PaintWidget(&o, image.Rect(0, 0, 2, 1), 1).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0x80, 0x40, 0x40, 0x80}, im.RGBAAt(0, 0))
+
+	// Fully opaque and red
+	im = render.PaintWidget(&o, image.Rect(0, 0, 2, 1), 2).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(1, 0))
+}
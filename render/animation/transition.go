@@ -0,0 +1,216 @@
+package animation
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+
+	"tidbyt.dev/pixlet/render"
+)
+
+// Transition effects.
+const (
+	TransitionSlide    = "slide"
+	TransitionPush     = "push"
+	TransitionWipe     = "wipe"
+	TransitionFade     = "fade"
+	TransitionDissolve = "dissolve"
+)
+
+// DefaultTransitionDuration is the number of frames a transition
+// lasts, unless it says otherwise.
+const DefaultTransitionDuration = 10
+
+// Transition moves from one widget to the next with an effect. It can
+// be passed to `render.Sequence` to go from each child to the next,
+// or to `render.Root` to go from the previous root to that one.
+//
+// The `effect` is one of:
+//
+// - `slide`: the next widget slides in on top of the previous one
+// - `push`: the next widget slides in and pushes the previous one out
+// - `wipe`: the next widget is revealed by an edge sweeping across
+// - `fade`: the previous widget fades to black, then the next one fades in
+// - `dissolve`: the previous widget fades straight into the next one
+//
+// Slides, pushes and wipes move in the given `direction`, which is
+// one of `left`, `right`, `up` or `down`, and defaults to `left`.
+//
+// The `duration` is expressed as a number of frames, and the
+// transition's progress follows the easing `curve`.
+//
+// DOC(Effect): Transition effect, one of 'slide', 'push', 'wipe', 'fade' or 'dissolve'
+// DOC(Duration): Duration of the transition (in frames), default is 10
+// DOC(Direction): Direction of movement, one of 'left', 'right', 'up' or 'down', default is 'left'
+// DOC(Curve): Easing curve to use, default is 'linear'
+//
+// EXAMPLE BEGIN
+// animation.Transition(
+//   effect = "push",
+//   duration = 15,
+//   direction = "up",
+//   curve = "ease_in_out",
+// )
+// EXAMPLE END
+type Transition struct {
+	Effect    string `starlark:"effect,required"`
+	Duration  int    `starlark:"duration"`
+	Direction string `starlark:"direction"`
+	Curve     Curve  `starlark:"curve"`
+}
+
+func (self *Transition) Init() error {
+	switch self.Effect {
+	case TransitionSlide, TransitionPush, TransitionWipe, TransitionFade, TransitionDissolve:
+	default:
+		return fmt.Errorf("invalid transition effect: %s", self.Effect)
+	}
+
+	switch self.Direction {
+	case "":
+		self.Direction = "left"
+	case "left", "right", "up", "down":
+	default:
+		return fmt.Errorf("invalid transition direction: %s", self.Direction)
+	}
+
+	if self.Duration < 0 {
+		return fmt.Errorf("transition duration must not be negative")
+	} else if self.Duration == 0 {
+		self.Duration = DefaultTransitionDuration
+	}
+
+	return nil
+}
+
+func (self *Transition) FrameCount() int {
+	if self.Duration <= 0 {
+		return DefaultTransitionDuration
+	}
+
+	return self.Duration
+}
+
+// Progress of the transition at frameIdx, in the range (0.0, 1.0).
+// Neither end is reached, since those frames are the widgets
+// themselves.
+func (self *Transition) progress(frameIdx int) float64 {
+	return float64(frameIdx+1) / float64(self.FrameCount()+1)
+}
+
+// Returns the offset that moves a widget off screen, in the direction
+// the transition moves in.
+func (self *Transition) offset(bounds image.Rectangle) Vec2f {
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+
+	switch self.Direction {
+	case "right":
+		return Vec2f{w, 0}
+	case "up":
+		return Vec2f{0, -h}
+	case "down":
+		return Vec2f{0, h}
+	default:
+		return Vec2f{-w, 0}
+	}
+}
+
+// Paints child while moving it between the translations from and to,
+// using the keyframe engine of Transformation.
+func (self *Transition) paintMoving(dc *gg.Context, bounds image.Rectangle, child render.Widget, from, to Vec2f, progress float64) {
+	curve := self.Curve
+	if curve == nil {
+		curve = DefaultCurve
+	}
+
+	keyframes := []Keyframe{
+		{Percentage{0.0}, []Transform{Translate{from}}, curve},
+		{Percentage{1.0}, []Transform{Translate{to}}, DefaultCurve},
+	}
+
+	dc.Push()
+	for _, transform := range transformsAt(keyframes, progress) {
+		transform.Apply(dc, Vec2f{}, Round{})
+	}
+	child.Paint(dc, bounds, 0)
+	dc.Pop()
+}
+
+func (self *Transition) PaintTransition(dc *gg.Context, bounds image.Rectangle, from, to render.Widget, frameIdx int) {
+	progress := self.progress(frameIdx)
+
+	curve := self.Curve
+	if curve == nil {
+		curve = DefaultCurve
+	}
+
+	// Nothing may be painted outside the bounds, since widgets are
+	// moved in from and out to beyond them.
+	dc.Push()
+	dc.DrawRectangle(0, 0, float64(bounds.Dx()), float64(bounds.Dy()))
+	dc.Clip()
+
+	offset := self.offset(bounds)
+	entry := Vec2f{-offset.X, -offset.Y}
+
+	switch self.Effect {
+	case TransitionSlide:
+		from.Paint(dc, bounds, 0)
+		self.paintMoving(dc, bounds, to, entry, Vec2f{}, progress)
+
+	case TransitionPush:
+		self.paintMoving(dc, bounds, from, Vec2f{}, offset, progress)
+		self.paintMoving(dc, bounds, to, entry, Vec2f{}, progress)
+
+	case TransitionWipe:
+		from.Paint(dc, bounds, 0)
+
+		// The revealed area grows from the edge the wipe starts at,
+		// in whole pixels.
+		p := curve.Transform(progress)
+		w, h := float64(bounds.Dx()), float64(bounds.Dy())
+		x, y, cw, ch := 0.0, 0.0, w, h
+		switch self.Direction {
+		case "right":
+			cw = math.Round(w * p)
+		case "up":
+			ch = math.Round(h * p)
+			y = h - ch
+		case "down":
+			ch = math.Round(h * p)
+		default:
+			cw = math.Round(w * p)
+			x = w - cw
+		}
+		dc.DrawRectangle(x, y, cw, ch)
+		dc.Clip()
+		to.Paint(dc, bounds, 0)
+
+	case TransitionFade:
+		dc.SetColor(color.Black)
+		dc.DrawRectangle(0, 0, float64(bounds.Dx()), float64(bounds.Dy()))
+		dc.Fill()
+
+		p := curve.Transform(progress)
+		if p < 0.5 {
+			render.PaintWithOpacity(dc, 1-2*p, func(dc *gg.Context) {
+				from.Paint(dc, bounds, 0)
+			})
+		} else {
+			render.PaintWithOpacity(dc, 2*p-1, func(dc *gg.Context) {
+				to.Paint(dc, bounds, 0)
+			})
+		}
+
+	case TransitionDissolve:
+		from.Paint(dc, bounds, 0)
+		render.PaintWithOpacity(dc, curve.Transform(progress), func(dc *gg.Context) {
+			to.Paint(dc, bounds, 0)
+		})
+	}
+
+	dc.Pop()
+}
@@ -0,0 +1,104 @@
+package animation
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"tidbyt.dev/pixlet/render"
+)
+
+func transitionSequence(t *testing.T, tr Transition) render.Sequence {
+	assert.NoError(t, tr.Init())
+
+	return render.Sequence{
+		Children: []render.Widget{
+			render.Box{Width: 4, Height: 2, Color: color.RGBA{0xff, 0, 0, 0xff}},
+			render.Box{Width: 4, Height: 2, Color: color.RGBA{0, 0, 0xff, 0xff}},
+		},
+		Transition: &tr,
+	}
+}
+
+func TestTransitionPush(t *testing.T) {
+	seq := transitionSequence(t, Transition{Effect: "push", Duration: 3})
+	assert.Equal(t, 5, seq.FrameCount())
+
+	for i, expected := range [][]string{
+		{"rrrr", "rrrr"},
+		{"rrrb", "rrrb"},
+		{"rrbb", "rrbb"},
+		{"rbbb", "rbbb"},
+		{"bbbb", "bbbb"},
+	} {
+		im := render.PaintWidget(seq, image.Rect(0, 0, 4, 2), i)
+		assert.Equal(t, nil, render.CheckImage(expected, im), "frame %d", i)
+	}
+}
+
+func TestTransitionSlideDirections(t *testing.T) {
+	for direction, expected := range map[string][]string{
+		"left":  {"rrbb", "rrbb"},
+		"right": {"bbrr", "bbrr"},
+		"up":    {"rrrr", "bbbb"},
+		"down":  {"bbbb", "rrrr"},
+	} {
+		seq := transitionSequence(t, Transition{Effect: "slide", Duration: 1, Direction: direction})
+		im := render.PaintWidget(seq, image.Rect(0, 0, 4, 2), 1)
+		assert.Equal(t, nil, render.CheckImage(expected, im), direction)
+	}
+}
+
+func TestTransitionWipe(t *testing.T) {
+	seq := transitionSequence(t, Transition{Effect: "wipe", Duration: 3, Direction: "right"})
+
+	for i, expected := range [][]string{
+		{"brrr", "brrr"},
+		{"bbrr", "bbrr"},
+		{"bbbr", "bbbr"},
+	} {
+		im := render.PaintWidget(seq, image.Rect(0, 0, 4, 2), i+1)
+		assert.Equal(t, nil, render.CheckImage(expected, im), "frame %d", i+1)
+	}
+}
+
+func TestTransitionFade(t *testing.T) {
+	seq := transitionSequence(t, Transition{Effect: "fade", Duration: 3})
+
+	// Fading out to black, through black, and in from black
+	for i, expected := range []color.RGBA{
+		{0x80, 0, 0, 0xff},
+		{0, 0, 0, 0xff},
+		{0, 0, 0x80, 0xff},
+	} {
+		im := render.PaintWidget(seq, image.Rect(0, 0, 4, 2), i+1).(*image.RGBA)
+		assert.Equal(t, expected, im.RGBAAt(0, 0), "frame %d", i+1)
+	}
+}
+
+func TestTransitionDissolve(t *testing.T) {
+	seq := transitionSequence(t, Transition{Effect: "dissolve", Duration: 1})
+
+	im := render.PaintWidget(seq, image.Rect(0, 0, 4, 2), 1).(*image.RGBA)
+	c := im.RGBAAt(0, 0)
+	assert.InDelta(t, 0x80, int(c.R), 1)
+	assert.InDelta(t, 0x80, int(c.B), 1)
+	assert.Equal(t, uint8(0xff), c.A)
+}
+
+func TestTransitionInit(t *testing.T) {
+	tr := Transition{Effect: "slide"}
+	assert.NoError(t, tr.Init())
+	assert.Equal(t, DefaultTransitionDuration, tr.FrameCount())
+	assert.Equal(t, "left", tr.Direction)
+
+	tr = Transition{Effect: "spin"}
+	assert.Error(t, tr.Init())
+
+	tr = Transition{Effect: "wipe", Direction: "sideways"}
+	assert.Error(t, tr.Init())
+
+	tr = Transition{Effect: "fade", Duration: -1}
+	assert.Error(t, tr.Init())
+}
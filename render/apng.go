@@ -0,0 +1,241 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+)
+
+// PNGSignature is the magic that every PNG file starts with.
+const PNGSignature = "\x89PNG\r\n\x1a\n"
+
+// APNG frame disposal and blend operations
+const (
+	apngDisposeNone       = 0
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+
+	apngBlendSource = 0
+	apngBlendOver   = 1
+)
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+type apngFrame struct {
+	width, height int
+	x, y          int
+	delay         int // in milliseconds
+	dispose       byte
+	blend         byte
+	data          [][]byte // image data, split as in the file
+}
+
+func readPNGChunks(data []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(data, []byte(PNGSignature)) {
+		return nil, errors.New("not a PNG")
+	}
+	data = data[len(PNGSignature):]
+
+	var chunks []pngChunk
+	for len(data) > 0 {
+		if len(data) < 12 {
+			return nil, errors.New("truncated chunk")
+		}
+		length := binary.BigEndian.Uint32(data[0:4])
+		if uint64(len(data)) < 12+uint64(length) {
+			return nil, errors.New("truncated chunk")
+		}
+		chunks = append(chunks, pngChunk{
+			typ:  string(data[4:8]),
+			data: data[8 : 8+length],
+		})
+		data = data[12+length:]
+	}
+
+	return chunks, nil
+}
+
+// WritePNGChunk writes a PNG chunk of type typ to buf, with its length
+// and CRC.
+func WritePNGChunk(buf *bytes.Buffer, typ string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(data)))
+	copy(header[4:8], typ)
+	buf.Write(header[:])
+	buf.Write(data)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:8])
+	crc.Write(data)
+	binary.BigEndian.PutUint32(header[0:4], crc.Sum32())
+	buf.Write(header[0:4])
+}
+
+func parseFCTL(data []byte) (*apngFrame, error) {
+	if len(data) != 26 {
+		return nil, errors.New("invalid fcTL chunk")
+	}
+
+	f := &apngFrame{
+		width:   int(binary.BigEndian.Uint32(data[4:8])),
+		height:  int(binary.BigEndian.Uint32(data[8:12])),
+		x:       int(binary.BigEndian.Uint32(data[12:16])),
+		y:       int(binary.BigEndian.Uint32(data[16:20])),
+		dispose: data[24],
+		blend:   data[25],
+	}
+
+	// The delay is given as a fraction of a second, where a zero
+	// denominator means 1/100s.
+	num := int(binary.BigEndian.Uint16(data[20:22]))
+	den := int(binary.BigEndian.Uint16(data[22:24]))
+	if den == 0 {
+		den = 100
+	}
+	f.delay = num * 1000 / den
+
+	return f, nil
+}
+
+// decodeAPNG decodes all frames of an animated PNG, along with the
+// duration of each frame in milliseconds. An error is returned if
+// the data is not an animated PNG.
+func decodeAPNG(data []byte) ([]image.Image, []int, error) {
+	chunks, err := readPNGChunks(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chunks) == 0 || chunks[0].typ != "IHDR" || len(chunks[0].data) != 13 {
+		return nil, nil, errors.New("missing IHDR")
+	}
+	ihdr := chunks[0].data
+
+	var (
+		animated bool
+		frames   []*apngFrame
+		shared   []pngChunk
+		current  *apngFrame
+		seenIDAT bool
+	)
+
+	for _, c := range chunks[1:] {
+		switch c.typ {
+		case "acTL":
+			animated = true
+
+		case "fcTL":
+			current, err = parseFCTL(c.data)
+			if err != nil {
+				return nil, nil, err
+			}
+			frames = append(frames, current)
+
+		case "IDAT":
+			seenIDAT = true
+
+			// The default image is only part of the
+			// animation if preceded by a fcTL.
+			if current != nil {
+				current.data = append(current.data, c.data)
+			}
+
+		case "fdAT":
+			if current == nil || len(c.data) < 4 {
+				return nil, nil, errors.New("invalid fdAT chunk")
+			}
+			current.data = append(current.data, c.data[4:])
+
+		case "IEND":
+
+		default:
+			// Chunks ahead of the image data (palette,
+			// transparency, gamma, ...) apply to all frames.
+			if !seenIDAT {
+				shared = append(shared, c)
+			}
+		}
+	}
+
+	if !animated || len(frames) == 0 {
+		return nil, nil, errors.New("not an animated PNG")
+	}
+
+	width := int(binary.BigEndian.Uint32(ihdr[0:4]))
+	height := int(binary.BigEndian.Uint32(ihdr[4:8]))
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	var images []image.Image
+	var delays []int
+
+	for i, f := range frames {
+		if len(f.data) == 0 {
+			continue
+		}
+
+		// Each frame is turned into a PNG of its own, for
+		// image/png to decode.
+		buf := &bytes.Buffer{}
+		buf.WriteString(PNGSignature)
+		frameIHDR := append([]byte{}, ihdr...)
+		binary.BigEndian.PutUint32(frameIHDR[0:4], uint32(f.width))
+		binary.BigEndian.PutUint32(frameIHDR[4:8], uint32(f.height))
+		WritePNGChunk(buf, "IHDR", frameIHDR)
+		for _, c := range shared {
+			WritePNGChunk(buf, c.typ, c.data)
+		}
+		for _, d := range f.data {
+			WritePNGChunk(buf, "IDAT", d)
+		}
+		WritePNGChunk(buf, "IEND", nil)
+
+		im, err := png.Decode(buf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decoding frame %d: %w", i, err)
+		}
+
+		region := image.Rect(f.x, f.y, f.x+f.width, f.y+f.height)
+
+		dispose := f.dispose
+		if dispose == apngDisposePrevious && i == 0 {
+			dispose = apngDisposeBackground
+		}
+
+		var previous *image.RGBA
+		if dispose == apngDisposePrevious {
+			previous = image.NewRGBA(region)
+			draw.Draw(previous, region, canvas, region.Min, draw.Src)
+		}
+
+		op := draw.Src
+		if f.blend == apngBlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, region, im, im.Bounds().Min, op)
+
+		frame := image.NewRGBA(canvas.Bounds())
+		copy(frame.Pix, canvas.Pix)
+		images = append(images, frame)
+		delays = append(delays, f.delay)
+
+		switch dispose {
+		case apngDisposeBackground:
+			draw.Draw(canvas, region, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			draw.Draw(canvas, region, previous, region.Min, draw.Src)
+		}
+	}
+
+	if len(images) == 0 {
+		return nil, nil, errors.New("no frames in animated PNG")
+	}
+
+	return images, delays, nil
+}
@@ -0,0 +1,159 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAPNGFrame struct {
+	im      image.Image
+	x, y    int
+	delay   int // in 1/100s
+	dispose byte
+	blend   byte
+}
+
+// Encodes an APNG of the given size. The first frame doubles as the
+// default image, and must cover the whole canvas.
+func encodeTestAPNG(t *testing.T, frames []testAPNGFrame) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(PNGSignature)
+
+	seq := uint32(0)
+	for i, f := range frames {
+		single := &bytes.Buffer{}
+		require.NoError(t, png.Encode(single, f.im))
+		chunks, err := readPNGChunks(single.Bytes())
+		require.NoError(t, err)
+
+		if i == 0 {
+			WritePNGChunk(buf, "IHDR", chunks[0].data)
+
+			actl := make([]byte, 8)
+			binary.BigEndian.PutUint32(actl[0:4], uint32(len(frames)))
+			WritePNGChunk(buf, "acTL", actl)
+		}
+
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:4], seq)
+		binary.BigEndian.PutUint32(fctl[4:8], uint32(f.im.Bounds().Dx()))
+		binary.BigEndian.PutUint32(fctl[8:12], uint32(f.im.Bounds().Dy()))
+		binary.BigEndian.PutUint32(fctl[12:16], uint32(f.x))
+		binary.BigEndian.PutUint32(fctl[16:20], uint32(f.y))
+		binary.BigEndian.PutUint16(fctl[20:22], uint16(f.delay))
+		binary.BigEndian.PutUint16(fctl[22:24], 100)
+		fctl[24] = f.dispose
+		fctl[25] = f.blend
+		WritePNGChunk(buf, "fcTL", fctl)
+		seq++
+
+		for _, c := range chunks {
+			if c.typ != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				WritePNGChunk(buf, "IDAT", c.data)
+				continue
+			}
+			fdat := make([]byte, 4, 4+len(c.data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			WritePNGChunk(buf, "fdAT", append(fdat, c.data...))
+			seq++
+		}
+	}
+
+	WritePNGChunk(buf, "IEND", nil)
+	return buf.Bytes()
+}
+
+func solidImage(w, h int, c color.Color) image.Image {
+	im := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			im.Set(x, y, c)
+		}
+	}
+	return im
+}
+
+func TestImageAPNG(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	green := color.RGBA{0, 0xff, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	data := encodeTestAPNG(t, []testAPNGFrame{
+		{im: solidImage(4, 2, red), delay: 10},
+		{im: solidImage(2, 1, green), x: 1, y: 1, delay: 20, dispose: apngDisposePrevious, blend: apngBlendOver},
+		{im: solidImage(1, 1, blue), x: 3, delay: 5, dispose: apngDisposeBackground},
+		{im: solidImage(1, 1, green), delay: 5, blend: apngBlendOver},
+	})
+
+	img := &Image{Src: string(data)}
+	require.NoError(t, img.Init())
+	assert.Equal(t, 4, img.FrameCount())
+	assert.Equal(t, 100, img.Delay)
+	assert.Equal(t, []int{100, 200, 50, 50}, img.durations)
+
+	expected := [][]string{
+		{"rrrr", "rrrr"},
+		{"rrrr", "rggr"},
+		{"rrrb", "rrrr"}, // green was disposed to previous
+		{"grr.", "rrrr"}, // blue was disposed to background
+	}
+	for i, exp := range expected {
+		im := PaintWidget(img, image.Rect(0, 0, 64, 32), i)
+		assert.Nil(t, checkImage(exp, im), "frame %d", i)
+	}
+}
+
+func TestImageAPNGFallback(t *testing.T) {
+	// A regular PNG is not an APNG, but still decodes as an image
+	raw := solidPNG(t, 3, 2, color.White)
+	_, _, err := decodeAPNG([]byte(raw))
+	assert.Error(t, err)
+
+	img := &Image{Src: raw}
+	require.NoError(t, img.Init())
+	assert.Equal(t, 1, img.FrameCount())
+}
+
+func TestImageWithFrameDelay(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	green := color.RGBA{0, 0xff, 0, 0xff}
+
+	data := encodeTestAPNG(t, []testAPNGFrame{
+		{im: solidImage(1, 1, red), delay: 10},
+		{im: solidImage(1, 1, green), delay: 30},
+	})
+
+	img := &Image{Src: string(data)}
+	require.NoError(t, img.Init())
+	assert.Equal(t, 2, img.FrameCount())
+
+	// At 50ms per frame, the 400ms animation spans 8 frames, of
+	// which the first 2 are red.
+	resampled := img.WithFrameDelay(50)
+	assert.Equal(t, 8, resampled.FrameCount())
+	for i, exp := range []string{"r", "r", "g", "g", "g", "g", "g", "g"} {
+		assert.Nil(t, checkImage([]string{exp}, PaintWidget(resampled, image.Rect(0, 0, 1, 1), i)))
+	}
+
+	// The original is left untouched
+	assert.Equal(t, 2, img.FrameCount())
+
+	// An animation already matching the delay is left as is
+	resampled = img.WithFrameDelay(100)
+	assert.Equal(t, 4, resampled.FrameCount())
+	assert.Same(t, resampled, resampled.(*Image).WithFrameDelay(100))
+
+	// Resampling happens when painting a Root
+	root := Root{Child: Row{Children: []Widget{img}}, Delay: 100}
+	assert.Equal(t, 4, len(root.Paint(false)))
+}
@@ -0,0 +1,96 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Arc draws part of the outline of a circle with the given
+// `diameter`.
+//
+// The arc runs clockwise from `start` to `end`, both given in degrees.
+// An angle of 0 points to the right, and 90 points straight down.
+//
+// Arcs are anti-aliased, unless `rendering` is set to `"crisp"`, in
+// which case they're snapped to whole pixels.
+//
+// DOC(Diameter): Diameter of the circle the arc is part of
+// DOC(Start): Start angle in degrees
+// DOC(End): End angle in degrees
+// DOC(Color): Line color, default is '#fff'
+// DOC(StrokeWidth): Line width, default is 1
+// DOC(Rendering): Either "smooth" or "crisp", default is "smooth"
+//
+// EXAMPLE BEGIN
+// render.Row(
+//      expanded=True,
+//      main_align="space_evenly",
+//      children=[
+//           render.Arc(diameter=24, start=180, end=360, color="#0af", stroke_width=3),
+//           render.Arc(diameter=24, start=0, end=270, color="#fa0"),
+//      ],
+// )
+// EXAMPLE END
+type Arc struct {
+	Widget
+
+	Diameter    int         `starlark:"diameter,required"`
+	Start       float64     `starlark:"start,required"`
+	End         float64     `starlark:"end,required"`
+	Color       color.Color `starlark:"color"`
+	StrokeWidth int         `starlark:"stroke_width"`
+	Rendering   string      `starlark:"rendering"`
+}
+
+func (a Arc) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return image.Rect(0, 0, a.Diameter, a.Diameter)
+}
+
+func (a Arc) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	col := color.Color(DefaultPlotColor)
+	if a.Color != nil {
+		col = a.Color
+	}
+
+	strokeWidth := a.StrokeWidth
+	if strokeWidth <= 0 {
+		strokeWidth = 1
+	}
+
+	r := float64(a.Diameter) / 2
+	arcR := math.Max(0, r-float64(strokeWidth)/2)
+
+	dc.Push()
+	dc.SetColor(col)
+	if a.Rendering == RenderingCrisp {
+		sw := float64(strokeWidth)
+		fillCrisp(dc, image.Rect(0, 0, a.Diameter, a.Diameter), func(x, y float64) bool {
+			return insideArc(x, y, r, r, arcR-sw/2, arcR+sw/2, gg.Radians(a.Start), gg.Radians(a.End))
+		})
+	} else {
+		dc.SetLineWidth(float64(strokeWidth))
+		dc.SetLineCapButt()
+		dc.NewSubPath()
+		dc.DrawArc(r, r, arcR, gg.Radians(a.Start), gg.Radians(a.End))
+		dc.Stroke()
+	}
+	dc.Pop()
+}
+
+func (a Arc) FrameCount() int {
+	return 1
+}
+
+func (a *Arc) Init() error {
+	return checkRendering(a.Rendering)
+}
+
+func (a Arc) WithDefaultRendering(rendering string) Widget {
+	if a.Rendering == "" {
+		a.Rendering = rendering
+	}
+	return a
+}
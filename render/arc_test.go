@@ -0,0 +1,28 @@
+package render
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArc(t *testing.T) {
+	a := Arc{Diameter: 10, Start: 180, End: 360, StrokeWidth: 2}
+	assert.Equal(t, image.Rect(0, 0, 10, 10), a.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+	assert.Equal(t, 1, a.FrameCount())
+
+	// Only the top half of the circle is drawn
+	im := PaintWidget(a, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.NotZero(t, im.RGBAAt(5, 0).A)
+	assert.NotZero(t, im.RGBAAt(0, 4).A)
+	assert.Zero(t, im.RGBAAt(5, 9).A)
+	assert.Zero(t, im.RGBAAt(5, 5).A)
+
+	// Crisp arcs have no partially transparent pixels
+	a.Rendering = RenderingCrisp
+	im = PaintWidget(a, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	for i := 3; i < len(im.Pix); i += 4 {
+		assert.True(t, im.Pix[i] == 0 || im.Pix[i] == 0xff)
+	}
+}
@@ -0,0 +1,193 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// BarChart draws a series of vertical bars.
+//
+// Each entry in `data` is either a number, producing a single bar,
+// or a list of numbers, producing a stacked bar. Positive values
+// grow upwards from the zero line and negative values grow
+// downwards. Unless `y_lim` is given, the Y-axis is scaled so that
+// all bars, and the zero line, fit in the chart.
+//
+// Bar colors are picked from `colors`, cycling through the list for
+// each bar. For stacked bars, colors are instead picked for each
+// segment of the stack. If `color_negative` is set, it is used for
+// all negative values.
+//
+// If `bar_width` is not given, bars are made as wide as possible
+// while still fitting in `width`.
+//
+// If `grow_frames` is set, the bars grow in from the zero line over
+// that many frames, and then remain at their full height.
+//
+// DOC(Data): List of bar values, or lists of values for stacked bars
+// DOC(Width): Width of the chart
+// DOC(Height): Height of the chart
+// DOC(Colors): List of bar colors, default is '#fff'
+// DOC(ColorNegative): Color for negative values
+// DOC(BarWidth): Width of each bar
+// DOC(BarGap): Space between bars
+// DOC(YLim): Limit Y-axis to a range
+// DOC(GrowFrames): Number of frames to animate bars growing in
+//
+// EXAMPLE BEGIN
+// render.BarChart(
+//      data=[3, 5, -2, (2, 4), 6, -4, (1, 1, 1)],
+//      width=64,
+//      height=32,
+//      bar_gap=2,
+//      colors=["#0af", "#fa0", "#f0a"],
+//      color_negative="#f00",
+// )
+// EXAMPLE END
+type BarChart struct {
+	Widget
+
+	Data          [][]float64   `starlark:"data,required"`
+	Width         int           `starlark:"width,required"`
+	Height        int           `starlark:"height,required"`
+	Colors        []color.Color `starlark:"colors"`
+	ColorNegative color.Color   `starlark:"color_negative"`
+	BarWidth      int           `starlark:"bar_width"`
+	BarGap        int           `starlark:"bar_gap"`
+	YLim          [2]float64    `starlark:"y_lim"`
+	GrowFrames    int           `starlark:"grow_frames"`
+}
+
+// Computes the Y-axis limits. The zero line is always included.
+func (b BarChart) computeLimits() (float64, float64) {
+	lo, hi := 0.0, 0.0
+	for _, bar := range b.Data {
+		pos, neg := 0.0, 0.0
+		for _, v := range bar {
+			if v > 0 {
+				pos += v
+			} else {
+				neg += v
+			}
+		}
+		hi = math.Max(hi, pos)
+		lo = math.Min(lo, neg)
+	}
+
+	if !math.IsNaN(b.YLim[0]) {
+		lo = b.YLim[0]
+	}
+	if !math.IsNaN(b.YLim[1]) {
+		hi = b.YLim[1]
+	}
+
+	// Non-sensical limits get an arbitrary range of 1, so that
+	// painting doesn't divide by zero.
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	return lo, hi
+}
+
+func (b BarChart) barWidth() int {
+	if b.BarWidth > 0 {
+		return b.BarWidth
+	}
+
+	n := len(b.Data)
+	if n == 0 {
+		return 0
+	}
+
+	w := (b.Width - b.BarGap*(n-1)) / n
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+func (b BarChart) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return image.Rect(0, 0, b.Width, b.Height)
+}
+
+func (b BarChart) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	lo, hi := b.computeLimits()
+	scale := growProgress(b.GrowFrames, frameIdx)
+
+	// Maps a value to a Y-coordinate on the canvas
+	toY := func(v float64) float64 {
+		return math.Round((hi - v) / (hi - lo) * float64(b.Height))
+	}
+
+	barW := b.barWidth()
+	for i, bar := range b.Data {
+		x := float64(i * (barW + b.BarGap))
+		if x >= float64(b.Width) {
+			break
+		}
+
+		pos, neg := 0.0, 0.0
+		for j, v := range bar {
+			col := color.Color(DefaultPlotColor)
+			if len(b.Colors) > 0 {
+				if len(bar) > 1 {
+					col = b.Colors[j%len(b.Colors)]
+				} else {
+					col = b.Colors[i%len(b.Colors)]
+				}
+			}
+			if v < 0 && b.ColorNegative != nil {
+				col = b.ColorNegative
+			}
+
+			var y0, y1 float64
+			if v >= 0 {
+				y0 = toY(pos * scale)
+				pos += v
+				y1 = toY(pos * scale)
+			} else {
+				y0 = toY(neg * scale)
+				neg += v
+				y1 = toY(neg * scale)
+			}
+
+			// Clamp to the chart area, so that values outside
+			// the Y limits don't spill over.
+			y0 = math.Max(0, math.Min(float64(b.Height), y0))
+			y1 = math.Max(0, math.Min(float64(b.Height), y1))
+			if y0 == y1 {
+				continue
+			}
+
+			dc.SetColor(col)
+			dc.DrawRectangle(x, math.Min(y0, y1), float64(barW), math.Abs(y1-y0))
+			dc.Fill()
+		}
+	}
+}
+
+func (b BarChart) FrameCount() int {
+	if b.GrowFrames > 1 {
+		return b.GrowFrames
+	}
+	return 1
+}
+
+// growProgress returns how far a "grow-in" animation of the given
+// number of frames has progressed at frameIdx, in the range [0, 1].
+// The animation eases out, so that it slows down towards the end.
+func growProgress(frames, frameIdx int) float64 {
+	if frames <= 1 || frameIdx >= frames-1 {
+		return 1.0
+	}
+	if frameIdx < 0 {
+		return 0.0
+	}
+
+	t := float64(frameIdx+1) / float64(frames)
+	return 1 - math.Pow(1-t, 3)
+}
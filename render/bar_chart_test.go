@@ -0,0 +1,95 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarChartPositiveAndNegative(t *testing.T) {
+	b := BarChart{
+		Data:          [][]float64{{2}, {-1}, {1}},
+		Width:         3,
+		Height:        3,
+		Colors:        []color.Color{color.RGBA{0, 0xff, 0, 0xff}},
+		ColorNegative: color.RGBA{0xff, 0, 0, 0xff},
+		YLim:          Empty,
+	}
+
+	assert.Equal(t, image.Rect(0, 0, 3, 3), b.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+	im := PaintWidget(b, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"g..",
+		"g.g",
+		".r.",
+	}, im))
+}
+
+func TestBarChartStacked(t *testing.T) {
+	b := BarChart{
+		Data:   [][]float64{{1, 2}, {3}},
+		Width:  5,
+		Height: 3,
+		BarGap: 1,
+		Colors: []color.Color{
+			color.RGBA{0xff, 0, 0, 0xff},
+			color.RGBA{0, 0, 0xff, 0xff},
+		},
+		YLim: Empty,
+	}
+
+	// Stacked segments cycle through the colors, while single
+	// value bars get colors by their position.
+	im := PaintWidget(b, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"bb.bb",
+		"bb.bb",
+		"rr.bb",
+	}, im))
+}
+
+func TestBarChartYLim(t *testing.T) {
+	b := BarChart{
+		Data:     [][]float64{{4}, {1}},
+		Width:    2,
+		Height:   2,
+		BarWidth: 1,
+		YLim:     [2]float64{0, 2},
+	}
+
+	// Values above the limit are clipped to the chart area
+	im := PaintWidget(b, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"w.",
+		"ww",
+	}, im))
+}
+
+func TestBarChartGrowFrames(t *testing.T) {
+	b := BarChart{
+		Data:       [][]float64{{4}},
+		Width:      1,
+		Height:     4,
+		YLim:       Empty,
+		GrowFrames: 4,
+	}
+
+	assert.Equal(t, 4, b.FrameCount())
+
+	heights := []int{}
+	for i := 0; i < b.FrameCount(); i++ {
+		im := PaintWidget(b, image.Rect(0, 0, 64, 32), i)
+		h := 0
+		for y := 0; y < 4; y++ {
+			if _, _, _, a := im.At(0, y).RGBA(); a > 0 {
+				h++
+			}
+		}
+		heights = append(heights, h)
+	}
+
+	// Bar grows in, easing out, and ends at full height
+	assert.Equal(t, []int{2, 3, 4, 4}, heights)
+}
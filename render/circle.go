@@ -12,9 +12,13 @@ import (
 // `child` widget is provided, it is drawn in the center of the
 // circle.
 //
+// The edge of the circle is anti-aliased, unless `rendering` is set
+// to `"crisp"`, in which case it's snapped to whole pixels.
+//
 // DOC(Child): Widget to place in the center of the circle
 // DOC(Color): Fill color
 // DOC(Diameter): Diameter of the circle
+// DOC(Rendering): Either "smooth" or "crisp", default is "smooth"
 //
 // EXAMPLE BEGIN
 // render.Circle(
@@ -29,6 +33,8 @@ type Circle struct {
 	Child    Widget
 	Color    color.Color `starlark:"color, required"`
 	Diameter int         `starlark:"diameter,required"`
+
+	Rendering string `starlark:"rendering"`
 }
 
 func (c Circle) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
@@ -36,11 +42,16 @@ func (c Circle) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangl
 }
 
 func (c Circle) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
-	dc.SetColor(c.Color)
-
 	r := float64(c.Diameter) / 2
-	dc.DrawCircle(r, r, r)
-	dc.Fill()
+	dc.SetColor(c.Color)
+	if c.Rendering == RenderingCrisp {
+		fillCrisp(dc, image.Rect(0, 0, c.Diameter, c.Diameter), func(x, y float64) bool {
+			return math.Hypot(x-r, y-r) <= r
+		})
+	} else {
+		dc.DrawCircle(r, r, r)
+		dc.Fill()
+	}
 
 	if c.Child != nil {
 		dc.Push()
@@ -69,3 +80,14 @@ func (c Circle) FrameCount() int {
 	}
 	return 1
 }
+
+func (c *Circle) Init() error {
+	return checkRendering(c.Rendering)
+}
+
+func (c Circle) WithDefaultRendering(rendering string) Widget {
+	if c.Rendering == "" {
+		c.Rendering = rendering
+	}
+	return c
+}
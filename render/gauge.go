@@ -0,0 +1,222 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// DefaultGaugeSweep is the angle, in degrees, covered by a Gauge
+// when no `sweep` is given.
+const DefaultGaugeSweep = 270
+
+// Styles of Gauge, deciding how the value is shown.
+const (
+	// Fill the arc from its start up to the value
+	GaugeStyleFill = "fill"
+
+	// Point a needle at the value
+	GaugeStyleNeedle = "needle"
+)
+
+// Gauge draws a circular dial showing where `value` falls within a
+// range.
+//
+// The dial is an arc of `sweep` degrees, open at the bottom. Its
+// range is given by `min` and `max`, which default to 0 and 100.
+// Values outside the range are clamped.
+//
+// With the default `"fill"` style, the arc is filled from its start
+// up to the value. With the `"needle"` style, a needle points at the
+// value instead.
+//
+// If a `child` widget is provided, it is drawn in the center of the
+// gauge.
+//
+// The arc and needle are anti-aliased, unless `rendering` is set to
+// `"crisp"`, in which case they're snapped to whole pixels.
+//
+// If `grow_frames` is set, the gauge animates from `min` to `value`
+// over that many frames, and then stays put.
+//
+// DOC(Value): Current value
+// DOC(Min): Value at start of the arc, default is 0
+// DOC(Max): Value at end of the arc, default is 100
+// DOC(Diameter): Diameter of the gauge
+// DOC(Thickness): Thickness of the arc, default is a sixth of the diameter
+// DOC(Sweep): Angle covered by the arc in degrees, default is 270
+// DOC(Style): Either "fill" or "needle", default is "fill"
+// DOC(Color): Color of fill or needle, default is '#fff'
+// DOC(BackgroundColor): Color of the arc track
+// DOC(Child): Widget to place in the center of the gauge
+// DOC(GrowFrames): Number of frames to animate the gauge
+// DOC(Rendering): Either "smooth" or "crisp", default is "smooth"
+//
+// EXAMPLE BEGIN
+// render.Row(
+//      expanded=True,
+//      main_align="space_evenly",
+//      children=[
+//           render.Gauge(
+//                value=65,
+//                diameter=28,
+//                color="#0af",
+//                background_color="#123",
+//                child=render.Text("65", font="tom-thumb"),
+//           ),
+//           render.Gauge(
+//                value=30,
+//                diameter=28,
+//                style="needle",
+//                color="#f00",
+//                background_color="#333",
+//           ),
+//      ],
+// )
+// EXAMPLE END
+type Gauge struct {
+	Widget
+
+	Value           float64     `starlark:"value,required"`
+	Min             float64     `starlark:"min"`
+	Max             *float64    `starlark:"max"`
+	Diameter        int         `starlark:"diameter,required"`
+	Thickness       int         `starlark:"thickness"`
+	Sweep           float64     `starlark:"sweep"`
+	Style           string      `starlark:"style"`
+	Color           color.Color `starlark:"color"`
+	BackgroundColor color.Color `starlark:"background_color"`
+	Child           Widget
+	GrowFrames      int    `starlark:"grow_frames"`
+	Rendering       string `starlark:"rendering"`
+}
+
+// Returns the start and sweep angle of the arc, in radians. The arc
+// is symmetric around the bottom of the gauge.
+func (g Gauge) arc() (float64, float64) {
+	sweep := g.Sweep
+	if sweep <= 0 || sweep > 360 {
+		sweep = DefaultGaugeSweep
+	}
+
+	start := 90 + (360-sweep)/2
+	return gg.Radians(start), gg.Radians(sweep)
+}
+
+func (g Gauge) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return image.Rect(0, 0, g.Diameter, g.Diameter)
+}
+
+func (g Gauge) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	col := color.Color(DefaultPlotColor)
+	if g.Color != nil {
+		col = g.Color
+	}
+
+	thickness := g.Thickness
+	if thickness <= 0 {
+		thickness = g.Diameter / 6
+		if thickness < 1 {
+			thickness = 1
+		}
+	}
+
+	r := float64(g.Diameter) / 2
+	arcR := r - float64(thickness)/2
+	start, sweep := g.arc()
+	fraction := valueFraction(g.Value, g.Min, g.Max) * growProgress(g.GrowFrames, frameIdx)
+	end := start + sweep*fraction
+
+	crisp := g.Rendering == RenderingCrisp
+	area := image.Rect(0, 0, g.Diameter, g.Diameter)
+	t := float64(thickness)
+
+	drawArc := func(a0, a1 float64) {
+		if crisp {
+			fillCrisp(dc, area, func(x, y float64) bool {
+				return insideArc(x, y, r, r, arcR-t/2, arcR+t/2, a0, a1)
+			})
+			return
+		}
+		dc.SetLineWidth(t)
+		dc.SetLineCapButt()
+		dc.NewSubPath()
+		dc.DrawArc(r, r, arcR, a0, a1)
+		dc.Stroke()
+	}
+
+	dc.Push()
+
+	if g.BackgroundColor != nil {
+		dc.SetColor(g.BackgroundColor)
+		drawArc(start, start+sweep)
+	}
+
+	if g.Style == GaugeStyleNeedle {
+		dc.SetColor(col)
+		w := math.Max(1, t/2)
+		x1, y1 := r+r*math.Cos(end), r+r*math.Sin(end)
+		if crisp {
+			fillCrisp(dc, area, func(x, y float64) bool {
+				return insideSegment(x, y, r, r, x1, y1, w, true)
+			})
+		} else {
+			dc.SetLineWidth(w)
+			dc.SetLineCapRound()
+			dc.NewSubPath()
+			dc.MoveTo(r, r)
+			dc.LineTo(x1, y1)
+			dc.Stroke()
+		}
+	} else if end > start {
+		dc.SetColor(col)
+		drawArc(start, end)
+	}
+
+	dc.Pop()
+
+	if g.Child != nil {
+		dc.Push()
+		childBounds := g.Child.PaintBounds(image.Rect(0, 0, g.Diameter, g.Diameter), frameIdx)
+
+		// Same centering as Circle
+		center := math.Ceil(float64(g.Diameter) / 2)
+		x := int(center) - int(0.5*float64(childBounds.Size().X))
+		y := int(center) - int(0.5*float64(childBounds.Size().Y))
+
+		dc.Translate(float64(x), float64(y))
+		g.Child.Paint(dc, image.Rect(0, 0, g.Diameter, g.Diameter), frameIdx)
+		dc.Pop()
+	}
+}
+
+func (g Gauge) FrameCount() int {
+	fc := 1
+	if g.GrowFrames > 1 {
+		fc = g.GrowFrames
+	}
+	if g.Child != nil && g.Child.FrameCount() > fc {
+		fc = g.Child.FrameCount()
+	}
+	return fc
+}
+
+func (g *Gauge) Init() error {
+	switch g.Style {
+	case "", GaugeStyleFill, GaugeStyleNeedle:
+	default:
+		return fmt.Errorf("invalid style: %s, must be %s or %s", g.Style, GaugeStyleFill, GaugeStyleNeedle)
+	}
+
+	return checkRendering(g.Rendering)
+}
+
+func (g Gauge) WithDefaultRendering(rendering string) Widget {
+	if g.Rendering == "" {
+		g.Rendering = rendering
+	}
+	return g
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaugeBoundsAndFrameCount(t *testing.T) {
+	g := Gauge{Value: 50, Diameter: 20}
+	assert.Equal(t, image.Rect(0, 0, 20, 20), g.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+	assert.Equal(t, 1, g.FrameCount())
+
+	g.GrowFrames = 10
+	assert.Equal(t, 10, g.FrameCount())
+
+	g.Child = Animation{Children: make([]Widget, 15)}
+	assert.Equal(t, 15, g.FrameCount())
+}
+
+func TestGaugeFill(t *testing.T) {
+	g := Gauge{
+		Value:           50,
+		Diameter:        20,
+		Thickness:       4,
+		Color:           color.RGBA{0xff, 0, 0, 0xff},
+		BackgroundColor: color.RGBA{0, 0, 0xff, 0xff},
+	}
+
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+
+	// Left side of the arc is filled, right side is track only
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(1, 10))
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, im.RGBAAt(18, 10))
+
+	// The bottom and center of the gauge are left empty
+	assert.Equal(t, color.RGBA{}, im.RGBAAt(10, 18))
+	assert.Equal(t, color.RGBA{}, im.RGBAAt(10, 10))
+}
+
+func TestGaugeNeedle(t *testing.T) {
+	g := Gauge{
+		Value:    50,
+		Diameter: 20,
+		Style:    "needle",
+	}
+
+	// At 50% of the default sweep, the needle points straight up
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.NotZero(t, im.RGBAAt(10, 4).A)
+	assert.Zero(t, im.RGBAAt(4, 10).A)
+	assert.Zero(t, im.RGBAAt(16, 10).A)
+}
+
+func TestGaugeChild(t *testing.T) {
+	g := Gauge{
+		Value:    0,
+		Diameter: 10,
+		Child:    Box{Width: 2, Height: 2, Color: color.RGBA{0, 0xff, 0, 0xff}},
+	}
+
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0, 0xff, 0, 0xff}, im.RGBAAt(4, 4))
+	assert.Equal(t, color.RGBA{0, 0xff, 0, 0xff}, im.RGBAAt(5, 5))
+	assert.Equal(t, color.RGBA{}, im.RGBAAt(3, 3))
+}
+
+func TestGaugeInit(t *testing.T) {
+	for _, style := range []string{"", "fill", "needle"} {
+		g := Gauge{Value: 50, Diameter: 20, Style: style}
+		assert.NoError(t, g.Init(), style)
+	}
+
+	g := Gauge{Value: 50, Diameter: 20, Style: "dial"}
+	assert.Error(t, g.Init())
+}
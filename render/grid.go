@@ -0,0 +1,348 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/tidbyt/gg"
+)
+
+// Grid lays out its children in rows and columns, like a table.
+//
+// Each entry in `rows` is a list of cells, and each cell is a widget
+// (or `None` to leave it empty). Rows may hold fewer cells than
+// there are columns.
+//
+// The width of each column is controlled by passing a list of specs
+// in `columns`:
+// - `"auto"`: as wide as the widest cell in the column (the default)
+// - `12` or `"12"`: a fixed width in pixels
+// - `"1fr"`, `"2fr"`, ...: a fraction of the space left over once
+//   all other columns and gaps have been accounted for
+//
+// Cells are aligned horizontally within their column according to
+// `column_align`, which holds one of `"start"`, `"center"` or `"end"`
+// per column. Vertical alignment within each row is controlled by
+// `cross_align`, using the same values. The height of a row is that
+// of its tallest cell.
+//
+// Gaps between rows and columns are set with `row_gap` and
+// `column_gap`. If `row_separator` or `column_separator` is given, a
+// line of that color is drawn in the middle of each gap. Gaps are at
+// least 1 pixel wide when a separator is drawn.
+//
+// DOC(Rows): List of rows, each a list of cell widgets
+// DOC(Columns): Width spec of each column, "auto", fixed pixels or fraction ("1fr")
+// DOC(ColumnAlign): Horizontal alignment of cells in each column
+// DOC(CrossAlign): Vertical alignment of cells within their row
+// DOC(RowGap): Space between rows
+// DOC(ColumnGap): Space between columns
+// DOC(RowSeparator): Color of line drawn between rows
+// DOC(ColumnSeparator): Color of line drawn between columns
+//
+// EXAMPLE BEGIN
+// render.Grid(
+//      columns=["1fr", "auto", 10],
+//      column_align=["start", "end", "end"],
+//      column_gap=2,
+//      row_separator="#333",
+//      rows=[
+//           [render.Text("NYC"), render.Text("12"), render.Text("3")],
+//           [render.Text("BOS"), render.Text("9"), render.Text("6")],
+//           [render.Text("TOR"), render.Text("7"), render.Text("8")],
+//      ],
+// )
+// EXAMPLE END
+type Grid struct {
+	Widget
+
+	Rows            [][]Widget  `starlark:"rows,required"`
+	Columns         []string    `starlark:"columns"`
+	ColumnAlign     []string    `starlark:"column_align"`
+	CrossAlign      string      `starlark:"cross_align"`
+	RowGap          int         `starlark:"row_gap"`
+	ColumnGap       int         `starlark:"column_gap"`
+	RowSeparator    color.Color `starlark:"row_separator"`
+	ColumnSeparator color.Color `starlark:"column_separator"`
+}
+
+// gridLayout holds the computed column widths and row heights of a
+// Grid for a given frame.
+type gridLayout struct {
+	colWidths  []int
+	rowHeights []int
+	rowGap     int
+	colGap     int
+	width      int
+	height     int
+}
+
+func (g Grid) numColumns() int {
+	n := len(g.Columns)
+	for _, row := range g.Rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	return n
+}
+
+func (g Grid) gaps() (int, int) {
+	rowGap, colGap := g.RowGap, g.ColumnGap
+	if rowGap < 1 && g.RowSeparator != nil {
+		rowGap = 1
+	}
+	if colGap < 1 && g.ColumnSeparator != nil {
+		colGap = 1
+	}
+	return rowGap, colGap
+}
+
+// parseColumnSpec returns the fixed width of a column spec, its
+// fraction if the spec is of the "Nfr" form, or auto if it's "auto".
+func parseColumnSpec(spec string) (fixed int, fraction int, auto bool, err error) {
+	spec = strings.TrimSpace(spec)
+
+	if spec == "auto" {
+		return 0, 0, true, nil
+	}
+
+	if strings.HasSuffix(spec, "fr") {
+		fr, err := strconv.Atoi(strings.TrimSuffix(spec, "fr"))
+		if err != nil || fr < 1 {
+			return 0, 0, false, fmt.Errorf("invalid column: %s, fraction must be a positive integer", spec)
+		}
+		return 0, fr, false, nil
+	}
+
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < 0 {
+		return 0, 0, false, fmt.Errorf("invalid column: %s, must be auto, a width of at least 0 or a fraction", spec)
+	}
+	return n, 0, false, nil
+}
+
+// Checks that align is one of the alignments of cells, or empty for
+// the default.
+func checkGridAlign(align string) error {
+	switch align {
+	case "", "start", "center", "end":
+		return nil
+	}
+	return fmt.Errorf("invalid alignment: %s, must be start, center or end", align)
+}
+
+func (g *Grid) Init() error {
+	for _, spec := range g.Columns {
+		if _, _, _, err := parseColumnSpec(spec); err != nil {
+			return err
+		}
+	}
+
+	for _, align := range g.ColumnAlign {
+		if err := checkGridAlign(align); err != nil {
+			return err
+		}
+	}
+
+	return checkGridAlign(g.CrossAlign)
+}
+
+func (g Grid) layout(bounds image.Rectangle, frameIdx int) gridLayout {
+	numCols := g.numColumns()
+	rowGap, colGap := g.gaps()
+
+	l := gridLayout{
+		colWidths:  make([]int, numCols),
+		rowHeights: make([]int, len(g.Rows)),
+		rowGap:     rowGap,
+		colGap:     colGap,
+	}
+
+	// Fixed and auto columns are sized first. Fraction columns
+	// then share whatever space remains.
+	fractions := make([]int, numCols)
+	totalFraction := 0
+	used := 0
+	for c := 0; c < numCols; c++ {
+		spec := "auto"
+		if c < len(g.Columns) {
+			spec = g.Columns[c]
+		}
+
+		// Specs are checked by Init, so errors can't happen here.
+		fixed, fraction, auto, _ := parseColumnSpec(spec)
+		switch {
+		case fraction > 0:
+			fractions[c] = fraction
+			totalFraction += fraction
+		case auto:
+			for _, row := range g.Rows {
+				if c < len(row) && row[c] != nil {
+					cb := row[c].PaintBounds(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), frameIdx)
+					if cb.Dx() > l.colWidths[c] {
+						l.colWidths[c] = cb.Dx()
+					}
+				}
+			}
+		default:
+			l.colWidths[c] = fixed
+		}
+
+		used += l.colWidths[c]
+	}
+
+	if numCols > 1 {
+		used += colGap * (numCols - 1)
+	}
+
+	if totalFraction > 0 {
+		remaining := bounds.Dx() - used
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		// Residual pixels go to the first fraction columns, one
+		// pixel at a time.
+		allotted := 0
+		for c := 0; c < numCols; c++ {
+			if fractions[c] > 0 {
+				l.colWidths[c] = remaining * fractions[c] / totalFraction
+				allotted += l.colWidths[c]
+			}
+		}
+		for c := 0; allotted < remaining && c < numCols; c++ {
+			if fractions[c] > 0 {
+				l.colWidths[c]++
+				allotted++
+			}
+		}
+	}
+
+	for c := 0; c < numCols; c++ {
+		l.width += l.colWidths[c]
+	}
+	if numCols > 1 {
+		l.width += colGap * (numCols - 1)
+	}
+
+	for r, row := range g.Rows {
+		for c, cell := range row {
+			if cell == nil {
+				continue
+			}
+			cb := cell.PaintBounds(image.Rect(0, 0, l.colWidths[c], bounds.Dy()), frameIdx)
+			if cb.Dy() > l.rowHeights[r] {
+				l.rowHeights[r] = cb.Dy()
+			}
+		}
+		l.height += l.rowHeights[r]
+	}
+	if len(g.Rows) > 1 {
+		l.height += rowGap * (len(g.Rows) - 1)
+	}
+
+	if l.width > bounds.Dx() {
+		l.width = bounds.Dx()
+	}
+	if l.height > bounds.Dy() {
+		l.height = bounds.Dy()
+	}
+
+	return l
+}
+
+func alignOffset(align string, available, size int) int {
+	switch align {
+	case "center":
+		return (available - size) / 2
+	case "end":
+		return available - size
+	}
+	return 0
+}
+
+func (g Grid) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	l := g.layout(bounds, frameIdx)
+	return image.Rect(0, 0, l.width, l.height)
+}
+
+func (g Grid) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	l := g.layout(bounds, frameIdx)
+
+	if g.RowSeparator != nil {
+		dc.SetColor(g.RowSeparator)
+		y := 0
+		for r := 0; r < len(l.rowHeights)-1; r++ {
+			y += l.rowHeights[r]
+			dc.DrawRectangle(0, float64(y+(l.rowGap-1)/2), float64(l.width), 1)
+			dc.Fill()
+			y += l.rowGap
+		}
+	}
+
+	if g.ColumnSeparator != nil {
+		dc.SetColor(g.ColumnSeparator)
+		x := 0
+		for c := 0; c < len(l.colWidths)-1; c++ {
+			x += l.colWidths[c]
+			dc.DrawRectangle(float64(x+(l.colGap-1)/2), 0, 1, float64(l.height))
+			dc.Fill()
+			x += l.colGap
+		}
+	}
+
+	y := 0
+	for r, row := range g.Rows {
+		if y >= bounds.Dy() {
+			break
+		}
+
+		x := 0
+		for c, cell := range row {
+			colW := l.colWidths[c]
+			if cell != nil && colW > 0 {
+				cellBounds := image.Rect(0, 0, colW, l.rowHeights[r])
+				cb := cell.PaintBounds(cellBounds, frameIdx)
+
+				align := "start"
+				if c < len(g.ColumnAlign) {
+					align = g.ColumnAlign[c]
+				}
+
+				dc.Push()
+				dc.Translate(float64(x), float64(y))
+				dc.DrawRectangle(0, 0, float64(colW), float64(l.rowHeights[r]))
+				dc.Clip()
+				dc.Translate(
+					float64(alignOffset(align, colW, cb.Dx())),
+					float64(alignOffset(g.CrossAlign, l.rowHeights[r], cb.Dy())),
+				)
+				cell.Paint(dc, cellBounds, frameIdx)
+				dc.Pop()
+			}
+
+			x += colW + l.colGap
+		}
+
+		y += l.rowHeights[r] + l.rowGap
+	}
+}
+
+func (g Grid) FrameCount() int {
+	m := 1
+	for _, row := range g.Rows {
+		for _, cell := range row {
+			if cell == nil {
+				continue
+			}
+			if c := cell.FrameCount(); c > m {
+				m = c
+			}
+		}
+	}
+	return m
+}
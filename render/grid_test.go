@@ -0,0 +1,135 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridAutoColumns(t *testing.T) {
+	g := Grid{
+		Rows: [][]Widget{
+			{Box{Width: 2, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}, Box{Width: 1, Height: 2, Color: color.RGBA{0, 0xff, 0, 0xff}}},
+			{Box{Width: 1, Height: 1, Color: color.RGBA{0, 0, 0xff, 0xff}}, Box{Width: 3, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}},
+		},
+	}
+
+	// Columns are as wide as their widest cell, rows as tall as
+	// their tallest cell.
+	assert.Equal(t, image.Rect(0, 0, 5, 3), g.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"rrg..",
+		"..g..",
+		"b.rrr",
+	}, im))
+}
+
+func TestGridFixedAndFractionColumns(t *testing.T) {
+	g := Grid{
+		Columns: []string{"2", "1fr", "2fr"},
+		Rows: [][]Widget{
+			{Box{Color: color.RGBA{0xff, 0, 0, 0xff}}, Box{Color: color.RGBA{0, 0xff, 0, 0xff}, Height: 1}, Box{Color: color.RGBA{0, 0, 0xff, 0xff}, Height: 1}},
+		},
+	}
+
+	// 9 pixels are left after the fixed column. The 1fr column
+	// gets 3 of them, the 2fr column gets 6.
+	im := PaintWidget(g, image.Rect(0, 0, 11, 1), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"rrgggbbbbbb",
+	}, im))
+
+	// Residual pixels are handed out to the first fraction columns.
+	im = PaintWidget(g, image.Rect(0, 0, 12, 1), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"rrggggbbbbbb",
+	}, im))
+}
+
+func TestGridAlignment(t *testing.T) {
+	g := Grid{
+		Columns:     []string{"4", "4"},
+		ColumnAlign: []string{"end", "center"},
+		CrossAlign:  "end",
+		Rows: [][]Widget{
+			{Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}, Box{Width: 2, Height: 3, Color: color.RGBA{0, 0xff, 0, 0xff}}},
+		},
+	}
+
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		".....gg.",
+		".....gg.",
+		"...r.gg.",
+	}, im))
+}
+
+func TestGridGapsAndSeparators(t *testing.T) {
+	g := Grid{
+		RowGap:          3,
+		ColumnGap:       1,
+		RowSeparator:    color.RGBA{0xff, 0xff, 0xff, 0xff},
+		ColumnSeparator: color.RGBA{0, 0, 0xff, 0xff},
+		Rows: [][]Widget{
+			{Box{Width: 2, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}, Box{Width: 2, Height: 1, Color: color.RGBA{0, 0xff, 0, 0xff}}},
+			{nil, Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}},
+		},
+	}
+
+	im := PaintWidget(g, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"rrbgg",
+		"..b..",
+		"wwbww",
+		"..b..",
+		"..br.",
+	}, im))
+
+	// A separator forces a gap of at least 1 pixel
+	g = Grid{
+		RowSeparator: color.RGBA{0xff, 0xff, 0xff, 0xff},
+		Rows: [][]Widget{
+			{Box{Width: 2, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}}},
+			{Box{Width: 2, Height: 1, Color: color.RGBA{0, 0xff, 0, 0xff}}},
+		},
+	}
+	im = PaintWidget(g, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"rr",
+		"ww",
+		"gg",
+	}, im))
+}
+
+func TestGridFrameCount(t *testing.T) {
+	g := Grid{
+		Rows: [][]Widget{
+			{Box{}, nil},
+			{Animation{Children: []Widget{Box{}, Box{}, Box{}}}},
+		},
+	}
+	assert.Equal(t, 3, g.FrameCount())
+}
+
+func TestGridInit(t *testing.T) {
+	valid := Grid{
+		Columns:     []string{"auto", "12", " 0 ", "2fr"},
+		ColumnAlign: []string{"start", "center", "end", ""},
+		CrossAlign:  "center",
+	}
+	assert.NoError(t, valid.Init())
+
+	for _, spec := range []string{"abc", "0fr", "-3", "-1fr", "fr", ""} {
+		g := Grid{Columns: []string{"auto", spec}}
+		assert.Error(t, g.Init(), spec)
+	}
+
+	g := Grid{ColumnAlign: []string{"start", "middle"}}
+	assert.Error(t, g.Init())
+
+	g = Grid{CrossAlign: "top"}
+	assert.Error(t, g.Init())
+}
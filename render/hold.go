@@ -0,0 +1,60 @@
+package render
+
+import (
+	"image"
+
+	"github.com/tidbyt/gg"
+)
+
+// Hold shows each frame of its child for `frames` frames.
+//
+// This is useful for keeping a slide on screen for a while before
+// moving on to an animation, without having to repeat it. A static
+// child held for 40 frames is shown for 2 seconds at the default
+// frame delay of 50 ms. An animated child plays `frames` times
+// slower.
+//
+// Consecutive frames that look the same are merged when the
+// animation is encoded, so holding a child doesn't make the result
+// any larger.
+//
+// DOC(Child): Widget to hold
+// DOC(Frames): Number of frames to show each frame of the child for
+//
+// EXAMPLE BEGIN
+// render.Sequence(
+//   children = [
+//     render.Hold(render.Text("Wait for it..."), frames = 40),
+//     render.Marquee(width = 64, child = render.Text("...there it goes!")),
+//   ],
+// )
+// EXAMPLE END
+type Hold struct {
+	Widget
+
+	Child  Widget `starlark:"child,required"`
+	Frames int    `starlark:"frames,required"`
+}
+
+// Maps a frame index onto the child's frames.
+func (h Hold) childFrame(frameIdx int) int {
+	if h.Frames <= 1 {
+		return frameIdx
+	}
+	return frameIdx / h.Frames
+}
+
+func (h Hold) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return h.Child.PaintBounds(bounds, h.childFrame(frameIdx))
+}
+
+func (h Hold) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	h.Child.Paint(dc, bounds, h.childFrame(frameIdx))
+}
+
+func (h Hold) FrameCount() int {
+	if h.Frames <= 1 {
+		return h.Child.FrameCount()
+	}
+	return h.Child.FrameCount() * h.Frames
+}
@@ -0,0 +1,34 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHold(t *testing.T) {
+	h := Hold{Child: Box{Width: 3, Height: 2}, Frames: 5}
+	assert.Equal(t, 5, h.FrameCount())
+	assert.Equal(t, image.Rect(0, 0, 3, 2), h.PaintBounds(image.Rect(0, 0, 64, 32), 4))
+
+	// Zero or one frame holds nothing
+	h.Frames = 0
+	assert.Equal(t, 1, h.FrameCount())
+}
+
+func TestHoldAnimation(t *testing.T) {
+	h := Hold{
+		Child: Animation{Children: []Widget{
+			Box{Width: 1, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}},
+			Box{Width: 1, Height: 1, Color: color.RGBA{0, 0xff, 0, 0xff}},
+		}},
+		Frames: 3,
+	}
+	assert.Equal(t, 6, h.FrameCount())
+
+	for i, exp := range []string{"r", "r", "r", "g", "g", "g"} {
+		assert.Nil(t, checkImage([]string{exp}, PaintWidget(h, image.Rect(0, 0, 1, 1), i)))
+	}
+}
@@ -14,34 +14,78 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 
-	"github.com/nfnt/resize"
 	"github.com/srwiley/oksvg"
 	"github.com/srwiley/rasterx"
 	"github.com/tidbyt/gg"
 )
 
 // Image renders the binary image data passed via `src`. Supported
-// formats include PNG, JPEG, GIF, and SVG.
+// formats include PNG, JPEG, GIF, WebP and SVG.
 //
 // If `width` or `height` are set, the image will be scaled
-// accordingly, with nearest neighbor interpolation. Otherwise the
-// image's original dimensions are used.
+// accordingly, with the `resample` algorithm described below, which
+// defaults to nearest neighbor. Otherwise the image's original
+// dimensions are used.
 //
-// If the image data encodes an animated GIF, the Image instance will
-// also be animated. Frame delay (in milliseconds) can be read from
-// the `delay` attribute.
+// If both `width` and `height` are set, `fit` decides how the image
+// is made to match them. The default, `"fill"`, stretches the image.
+// With `"contain"`, the image is scaled to fit inside and centered,
+// leaving the rest transparent. With `"cover"`, the image is scaled
+// to cover the whole area and the edges are cropped. The `resample`
+// algorithm used for scaling can be `"nearest"`, `"bilinear"`,
+// `"bicubic"`, `"mitchell"` or `"lanczos"`.
+//
+// Colors can be adjusted with `brightness`, `contrast` and
+// `saturation`, which all default to 0 and range from -1 to 1. A
+// saturation of -1 is the same as setting `grayscale`. If a `tint` is
+// given, the image is made grayscale and then colored with the tint.
+//
+// Finally, the image can be reduced to the colors in `palette`. With
+// `dither` set to `"ordered"` or `"floyd-steinberg"`, the image is
+// dithered while doing so, and the palette defaults to the eight
+// colors with each channel fully on or off.
+//
+// All of this is done once, when the image is loaded.
+//
+// If the image data encodes an animated GIF, WebP or PNG, the Image
+// instance will also be animated. Delay of the first frame (in
+// milliseconds) can be read from the `delay` attribute. When painted
+// as part of a Root, the animation is resampled to the Root's frame
+// delay, so that it plays at the speed it was made for even if its
+// frames have varying durations.
 //
 // DOC(Src): Binary image data or SVG text
 // DOC(Width): Scale image to this width
 // DOC(Height): Scale image to this height
-// DOC(Delay): (Read-only) Frame delay in ms, for animated GIFs
+// DOC(Fit): How to scale to both width and height, "fill", "contain" or "cover"
+// DOC(Resample): Scaling algorithm, default is "nearest"
+// DOC(Brightness): Brightness adjustment, from -1 to 1
+// DOC(Contrast): Contrast adjustment, from -1 to 1
+// DOC(Saturation): Saturation adjustment, from -1 to 1
+// DOC(Grayscale): Convert image to grayscale
+// DOC(Tint): Color to tint a grayscale version of the image with
+// DOC(Dither): Dithering algorithm, "none", "ordered" or "floyd-steinberg"
+// DOC(Palette): List of colors to reduce the image to
+// DOC(Delay): (Read-only) Frame delay in ms, for animated images
 type Image struct {
 	Widget
 	Src           string `starlark:"src,required"`
 	Width, Height int
-	Delay         int `starlark:"delay,readonly"`
+	Fit           string        `starlark:"fit"`
+	Resample      string        `starlark:"resample"`
+	Brightness    float64       `starlark:"brightness"`
+	Contrast      float64       `starlark:"contrast"`
+	Saturation    float64       `starlark:"saturation"`
+	Grayscale     bool          `starlark:"grayscale"`
+	Tint          color.Color   `starlark:"tint"`
+	Dither        string        `starlark:"dither"`
+	Palette       []color.Color `starlark:"palette"`
+	Delay         int           `starlark:"delay,readonly"`
 
 	imgs []image.Image
+
+	// Duration of each frame in milliseconds, if known
+	durations []int
 }
 
 func (p *Image) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
@@ -68,6 +112,9 @@ func (p *Image) InitFromGIF(data []byte) error {
 	}
 
 	p.Delay = img.Delay[0] * 10
+	for _, d := range img.Delay {
+		p.durations = append(p.durations, d*10)
+	}
 
 	var prev_src *image.Paletted
 	disposal_length := len(img.Disposal)
@@ -120,6 +167,19 @@ func (p *Image) InitFromGIF(data []byte) error {
 	return nil
 }
 
+func (p *Image) InitFromAPNG(data []byte) error {
+	imgs, durations, err := decodeAPNG(data)
+	if err != nil {
+		return fmt.Errorf("decoding image data: %v", err)
+	}
+
+	p.imgs = imgs
+	p.durations = durations
+	p.Delay = durations[0]
+
+	return nil
+}
+
 func (p *Image) InitFromImage(data []byte) error {
 	im, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
@@ -159,13 +219,20 @@ func (p *Image) InitFromSVG(data []byte) error {
 }
 
 func (p *Image) Init() error {
+	if err := p.validateFilters(); err != nil {
+		return err
+	}
+
 	err := p.InitFromWebP([]byte(p.Src))
 	if err != nil {
 		err = p.InitFromGIF([]byte(p.Src))
 		if err != nil {
-			err = p.InitFromSVG([]byte(p.Src))
+			err = p.InitFromAPNG([]byte(p.Src))
 			if err != nil {
-				err = p.InitFromImage([]byte(p.Src))
+				err = p.InitFromSVG([]byte(p.Src))
+				if err != nil {
+					err = p.InitFromImage([]byte(p.Src))
+				}
 			}
 		}
 	}
@@ -174,24 +241,52 @@ func (p *Image) Init() error {
 		return err
 	}
 
-	w := p.imgs[0].Bounds().Dx()
-	h := p.imgs[0].Bounds().Dy()
+	p.scaleImages()
+	p.filterImages()
 
-	if p.Width != 0 || p.Height != 0 {
-		nw, nh := p.Width, p.Height
-		if nw == 0 {
-			// scale width, maintaining original aspect ratio
-			nw = int(float64(nh) * (float64(w) / float64(h)))
-		}
-		if nh == 0 {
-			// scale height, maintaining original aspect ratio
-			nh = int(float64(nw) * (float64(h) / float64(w)))
+	return nil
+}
+
+// WithFrameDelay returns a copy of the image with its frames
+// resampled to the given frame delay, in milliseconds. Frames are
+// repeated or dropped as needed to keep the animation's timing.
+func (p *Image) WithFrameDelay(delay int) Widget {
+	if delay <= 0 || len(p.imgs) < 2 || len(p.durations) != len(p.imgs) {
+		return p
+	}
+
+	total := 0
+	uniform := true
+	for _, d := range p.durations {
+		total += d
+		if d != delay {
+			uniform = false
 		}
+	}
+	if uniform || total <= 0 {
+		return p
+	}
 
-		for i := 0; i < len(p.imgs); i++ {
-			p.imgs[i] = resize.Resize(uint(nw), uint(nh), p.imgs[i], resize.NearestNeighbor)
+	n := (total + delay/2) / delay
+	if n < 1 {
+		n = 1
+	}
+
+	imgs := make([]image.Image, n)
+	durations := make([]int, n)
+	frame, frameEnd := 0, p.durations[0]
+	for i := 0; i < n; i++ {
+		t := i * delay
+		for t >= frameEnd && frame < len(p.imgs)-1 {
+			frame++
+			frameEnd += p.durations[frame]
 		}
+		imgs[i] = p.imgs[frame]
+		durations[i] = delay
 	}
 
-	return nil
+	resampled := *p
+	resampled.imgs = imgs
+	resampled.durations = durations
+	return &resampled
 }
@@ -0,0 +1,306 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/nfnt/resize"
+)
+
+// Fit modes for Image, deciding how an image is scaled when both
+// width and height are given.
+const (
+	// Stretch the image to exactly the given size
+	FitFill = "fill"
+
+	// Scale the image to fit inside the given size, keeping its
+	// aspect ratio, and center it on a transparent background
+	FitContain = "contain"
+
+	// Scale the image to cover the given size, keeping its aspect
+	// ratio, and crop whatever ends up outside
+	FitCover = "cover"
+)
+
+// Dithering algorithms for Image.
+const (
+	DitherNone           = "none"
+	DitherOrdered        = "ordered"
+	DitherFloydSteinberg = "floyd-steinberg"
+)
+
+var resampleFunctions = map[string]resize.InterpolationFunction{
+	"":         resize.NearestNeighbor,
+	"nearest":  resize.NearestNeighbor,
+	"bilinear": resize.Bilinear,
+	"bicubic":  resize.Bicubic,
+	"mitchell": resize.MitchellNetravali,
+	"lanczos":  resize.Lanczos3,
+}
+
+// DefaultDitherPalette is used when dithering without a palette. It
+// holds the eight colors with each channel either fully on or off.
+var DefaultDitherPalette = []color.Color{
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0xff, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xff, 0x00, 0xff},
+	color.RGBA{0x00, 0x00, 0xff, 0xff},
+	color.RGBA{0xff, 0xff, 0x00, 0xff},
+	color.RGBA{0xff, 0x00, 0xff, 0xff},
+	color.RGBA{0x00, 0xff, 0xff, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+}
+
+// 4x4 Bayer matrix, for ordered dithering
+var bayer4x4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// Checks that the filter options of an Image are valid.
+func (p *Image) validateFilters() error {
+	switch p.Fit {
+	case "", FitFill, FitContain, FitCover:
+	default:
+		return fmt.Errorf("invalid fit: %q", p.Fit)
+	}
+
+	if _, ok := resampleFunctions[p.Resample]; !ok {
+		return fmt.Errorf("invalid resample: %q", p.Resample)
+	}
+
+	switch p.Dither {
+	case "", DitherNone, DitherOrdered, DitherFloydSteinberg:
+	default:
+		return fmt.Errorf("invalid dither: %q", p.Dither)
+	}
+
+	return nil
+}
+
+// scaleImages scales all frames according to width, height and fit.
+func (p *Image) scaleImages() {
+	w := p.imgs[0].Bounds().Dx()
+	h := p.imgs[0].Bounds().Dy()
+
+	if p.Width == 0 && p.Height == 0 {
+		return
+	}
+
+	nw, nh := p.Width, p.Height
+	if nw == 0 {
+		// scale width, maintaining original aspect ratio
+		nw = int(float64(nh) * (float64(w) / float64(h)))
+	}
+	if nh == 0 {
+		// scale height, maintaining original aspect ratio
+		nh = int(float64(nw) * (float64(h) / float64(w)))
+	}
+
+	interp := resampleFunctions[p.Resample]
+
+	// Without both dimensions, or when stretching, there's no
+	// aspect ratio to preserve.
+	if p.Width == 0 || p.Height == 0 || p.Fit == "" || p.Fit == FitFill {
+		for i := 0; i < len(p.imgs); i++ {
+			p.imgs[i] = resize.Resize(uint(nw), uint(nh), p.imgs[i], interp)
+		}
+		return
+	}
+
+	scale := math.Min(float64(nw)/float64(w), float64(nh)/float64(h))
+	if p.Fit == FitCover {
+		scale = math.Max(float64(nw)/float64(w), float64(nh)/float64(h))
+	}
+	sw := int(math.Round(float64(w) * scale))
+	sh := int(math.Round(float64(h) * scale))
+
+	// Center the scaled image on the target canvas. For cover,
+	// this crops the overflowing edges.
+	offset := image.Pt((nw-sw)/2, (nh-sh)/2)
+
+	for i := 0; i < len(p.imgs); i++ {
+		scaled := resize.Resize(uint(sw), uint(sh), p.imgs[i], interp)
+		canvas := image.NewRGBA(image.Rect(0, 0, nw, nh))
+		draw.Draw(canvas, scaled.Bounds().Add(offset), scaled, scaled.Bounds().Min, draw.Src)
+		p.imgs[i] = canvas
+	}
+}
+
+// Reports whether any color adjustments are requested.
+func (p *Image) hasColorFilters() bool {
+	return p.Brightness != 0 ||
+		p.Contrast != 0 ||
+		p.Saturation != 0 ||
+		p.Grayscale ||
+		p.Tint != nil ||
+		(p.Dither != "" && p.Dither != DitherNone) ||
+		len(p.Palette) > 0
+}
+
+// filterImages applies color adjustments and dithering to all frames.
+func (p *Image) filterImages() {
+	if !p.hasColorFilters() {
+		return
+	}
+
+	for i, im := range p.imgs {
+		p.imgs[i] = p.filterImage(im)
+	}
+}
+
+func (p *Image) filterImage(im image.Image) image.Image {
+	b := im.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), im, b.Min, draw.Src)
+
+	var tr, tg, tb float64
+	if p.Tint != nil {
+		c := color.NRGBAModel.Convert(p.Tint).(color.NRGBA)
+		tr, tg, tb = float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff
+	}
+
+	for i := 0; i < len(out.Pix); i += 4 {
+		r := float64(out.Pix[i]) / 0xff
+		g := float64(out.Pix[i+1]) / 0xff
+		bl := float64(out.Pix[i+2]) / 0xff
+
+		// Brightness and contrast, with contrast pivoting
+		// around mid-gray.
+		r = (r-0.5)*(1+p.Contrast) + 0.5 + p.Brightness
+		g = (g-0.5)*(1+p.Contrast) + 0.5 + p.Brightness
+		bl = (bl-0.5)*(1+p.Contrast) + 0.5 + p.Brightness
+
+		// Saturation interpolates away from, or towards, the
+		// pixel's luminance.
+		lum := luminance(r, g, bl)
+		if p.Grayscale || p.Tint != nil {
+			r, g, bl = lum, lum, lum
+		} else if p.Saturation != 0 {
+			s := 1 + p.Saturation
+			r = lum + (r-lum)*s
+			g = lum + (g-lum)*s
+			bl = lum + (bl-lum)*s
+		}
+
+		if p.Tint != nil {
+			r, g, bl = r*tr, g*tg, bl*tb
+		}
+
+		out.Pix[i] = clampChannel(r)
+		out.Pix[i+1] = clampChannel(g)
+		out.Pix[i+2] = clampChannel(bl)
+	}
+
+	palette := p.Palette
+	if len(palette) == 0 && p.Dither != "" && p.Dither != DitherNone {
+		palette = DefaultDitherPalette
+	}
+	if len(palette) > 0 {
+		ditherImage(out, palette, p.Dither)
+	}
+
+	return out
+}
+
+// Relative luminance of a color, per ITU-R BT.709.
+func luminance(r, g, b float64) float64 {
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+func clampChannel(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 0xff))
+}
+
+// Returns the largest number of distinct values any channel takes in
+// the palette, and at least 2.
+func paletteLevels(pal [][3]float64) int {
+	levels := 2
+	for k := 0; k < 3; k++ {
+		values := map[float64]bool{}
+		for _, c := range pal {
+			values[c[k]] = true
+		}
+		if len(values) > levels {
+			levels = len(values)
+		}
+	}
+	return levels
+}
+
+// ditherImage reduces the colors of im to those in palette, using
+// the given dithering algorithm. Alpha is left untouched.
+func ditherImage(im *image.NRGBA, palette []color.Color, algorithm string) {
+	pal := make([][3]float64, len(palette))
+	for i, c := range palette {
+		nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+		pal[i] = [3]float64{float64(nc.R), float64(nc.G), float64(nc.B)}
+	}
+
+	nearest := func(c [3]float64) [3]float64 {
+		best, bestDist := pal[0], math.Inf(1)
+		for _, pc := range pal {
+			dr, dg, db := c[0]-pc[0], c[1]-pc[1], c[2]-pc[2]
+			if d := dr*dr + dg*dg + db*db; d < bestDist {
+				best, bestDist = pc, d
+			}
+		}
+		return best
+	}
+
+	// Spread of the ordered dither offset. Half the distance between
+	// adjacent levels of a channel, assuming the palette's levels are
+	// evenly spaced, as in the default palette or an n-bit one.
+	spread := 0xff / 2.0 / float64(paletteLevels(pal)-1)
+
+	w, h := im.Bounds().Dx(), im.Bounds().Dy()
+
+	// Accumulated Floyd-Steinberg error, for this row and the next
+	errCur := make([][3]float64, w+2)
+	errNext := make([][3]float64, w+2)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := im.PixOffset(x, y)
+			c := [3]float64{float64(im.Pix[i]), float64(im.Pix[i+1]), float64(im.Pix[i+2])}
+
+			switch algorithm {
+			case DitherOrdered:
+				offset := (bayer4x4[y%4][x%4]/16 - 0.5 + 1.0/32) * spread
+				for k := range c {
+					c[k] += offset
+				}
+			case DitherFloydSteinberg:
+				for k := range c {
+					c[k] += errCur[x+1][k]
+				}
+			}
+
+			q := nearest(c)
+
+			if algorithm == DitherFloydSteinberg {
+				for k := range c {
+					e := c[k] - q[k]
+					errCur[x+2][k] += e * 7 / 16
+					errNext[x][k] += e * 3 / 16
+					errNext[x+1][k] += e * 5 / 16
+					errNext[x+2][k] += e * 1 / 16
+				}
+			}
+
+			im.Pix[i] = uint8(q[0])
+			im.Pix[i+1] = uint8(q[1])
+			im.Pix[i+2] = uint8(q[2])
+		}
+
+		errCur, errNext = errNext, errCur
+		for x := range errNext {
+			errNext[x] = [3]float64{}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Encodes a w x h PNG filled with a single color.
+func solidPNG(t *testing.T, w, h int, c color.Color) string {
+	im := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			im.Set(x, y, c)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, im))
+	return buf.String()
+}
+
+func TestImageFitContain(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(testPNG)
+	img := &Image{Src: string(raw), Width: 20, Height: 6, Fit: FitContain}
+	require.NoError(t, img.Init())
+
+	w, h := img.Size()
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 6, h)
+
+	// The 10x12 image is scaled to 5x6 and centered
+	im := PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Zero(t, im.RGBAAt(6, 0).A)
+	assert.NotZero(t, im.RGBAAt(7, 0).A)
+	assert.NotZero(t, im.RGBAAt(11, 5).A)
+	assert.Zero(t, im.RGBAAt(12, 5).A)
+}
+
+func TestImageFitCover(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(testPNG)
+	img := &Image{Src: string(raw), Width: 20, Height: 6, Fit: FitCover}
+	require.NoError(t, img.Init())
+
+	w, h := img.Size()
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 6, h)
+
+	// The image is scaled to 20x24, so the top and bottom
+	// borders are cropped away, but the side borders remain.
+	im := PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(0, 0))
+	assert.Zero(t, im.RGBAAt(4, 0).A)
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(19, 5))
+}
+
+func TestImageColorAdjustments(t *testing.T) {
+	src := solidPNG(t, 2, 2, color.RGBA{0xff, 0x00, 0x00, 0xff})
+
+	img := &Image{Src: src, Grayscale: true}
+	require.NoError(t, img.Init())
+	im := PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0x36, 0x36, 0x36, 0xff}, im.RGBAAt(0, 0))
+
+	img = &Image{Src: src, Brightness: -0.5}
+	require.NoError(t, img.Init())
+	im = PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0x80, 0, 0, 0xff}, im.RGBAAt(0, 0))
+
+	img = &Image{Src: src, Contrast: -1}
+	require.NoError(t, img.Init())
+	im = PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0x80, 0x80, 0x80, 0xff}, im.RGBAAt(0, 0))
+
+	img = &Image{Src: solidPNG(t, 2, 2, color.RGBA{0xff, 0xff, 0xff, 0xff}), Tint: color.RGBA{0, 0xff, 0x80, 0xff}}
+	require.NoError(t, img.Init())
+	im = PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0, 0xff, 0x80, 0xff}, im.RGBAAt(0, 0))
+}
+
+func TestImageDither(t *testing.T) {
+	src := solidPNG(t, 8, 8, color.RGBA{0x80, 0x80, 0x80, 0xff})
+	palette := []color.Color{
+		color.RGBA{0, 0, 0, 0xff},
+		color.RGBA{0xff, 0xff, 0xff, 0xff},
+	}
+
+	countWhite := func(im *image.RGBA) int {
+		n := 0
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				switch im.RGBAAt(x, y) {
+				case color.RGBA{0xff, 0xff, 0xff, 0xff}:
+					n++
+				case color.RGBA{0, 0, 0, 0xff}:
+				default:
+					t.Errorf("pixel %d,%d not in palette", x, y)
+				}
+			}
+		}
+		return n
+	}
+
+	// Without dithering, every pixel snaps to the same color
+	img := &Image{Src: src, Palette: palette}
+	require.NoError(t, img.Init())
+	n := countWhite(PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA))
+	assert.True(t, n == 0 || n == 64)
+
+	// With dithering, about half the pixels are white
+	for _, dither := range []string{DitherOrdered, DitherFloydSteinberg} {
+		img = &Image{Src: src, Palette: palette, Dither: dither}
+		require.NoError(t, img.Init())
+		n = countWhite(PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA))
+		assert.InDelta(t, 32, n, 4, dither)
+	}
+}
+
+func TestImageDitherLevels(t *testing.T) {
+	// Four levels of gray, so ordered dithering spreads over a
+	// quarter of the range, and colors in the palette stay put
+	palette := []color.Color{
+		color.RGBA{0, 0, 0, 0xff},
+		color.RGBA{0x55, 0x55, 0x55, 0xff},
+		color.RGBA{0xaa, 0xaa, 0xaa, 0xff},
+		color.RGBA{0xff, 0xff, 0xff, 0xff},
+	}
+
+	src := solidPNG(t, 4, 4, color.RGBA{0x55, 0x55, 0x55, 0xff})
+	img := &Image{Src: src, Palette: palette, Dither: DitherOrdered}
+	require.NoError(t, img.Init())
+	im := PaintWidget(img, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			assert.Equal(t, color.RGBA{0x55, 0x55, 0x55, 0xff}, im.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestImageInvalidFilters(t *testing.T) {
+	src := solidPNG(t, 2, 2, color.White)
+	assert.Error(t, (&Image{Src: src, Fit: "squish"}).Init())
+	assert.Error(t, (&Image{Src: src, Resample: "magic"}).Init())
+	assert.Error(t, (&Image{Src: src, Dither: "noise"}).Init())
+}
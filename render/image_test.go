@@ -130,6 +130,7 @@ func TestImageAnimatedGif(t *testing.T) {
 
 	// 4 frames in this animation
 	assert.Equal(t, 4, img.FrameCount())
+	assert.Equal(t, []int{1230, 1230, 1230, 1230}, img.durations)
 
 	// black pixels moving right
 	assert.Equal(t, nil, checkImage([]string{
@@ -19,9 +19,13 @@ func (p *Image) InitFromWebP(data []byte) error {
 		return fmt.Errorf("decoding image data: %v", err)
 	}
 
+	// Timestamps mark the end of each frame
 	p.Delay = img.Timestamp[0]
-	for _, im := range img.Image {
+	prev := 0
+	for i, im := range img.Image {
 		p.imgs = append(p.imgs, im)
+		p.durations = append(p.durations, img.Timestamp[i]-prev)
+		prev = img.Timestamp[i]
 	}
 
 	return nil
@@ -0,0 +1,297 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Rendering modes for shape widgets. Smooth shapes are anti-aliased,
+// while crisp shapes have their edges snapped to whole pixels.
+const (
+	RenderingSmooth = "smooth"
+	RenderingCrisp  = "crisp"
+)
+
+// Blend modes used when compositing a widget onto what's already
+// been painted below it.
+const (
+	BlendNormal   = "normal"
+	BlendAdditive = "additive"
+	BlendMultiply = "multiply"
+	BlendScreen   = "screen"
+)
+
+// Checks that rendering is one of the rendering modes, or empty for
+// the default.
+func checkRendering(rendering string) error {
+	switch rendering {
+	case "", RenderingSmooth, RenderingCrisp:
+		return nil
+	}
+	return fmt.Errorf("invalid rendering: %s, must be %s or %s", rendering, RenderingSmooth, RenderingCrisp)
+}
+
+// Checks that blend is one of the blend modes, or empty for the
+// default.
+func checkBlend(blend string) error {
+	switch blend {
+	case "", BlendNormal, BlendAdditive, BlendMultiply, BlendScreen:
+		return nil
+	}
+	return fmt.Errorf(
+		"invalid blend: %s, must be one of %s, %s, %s or %s",
+		blend, BlendNormal, BlendAdditive, BlendMultiply, BlendScreen,
+	)
+}
+
+// WidgetWithRendering is implemented by widgets that can be painted
+// either smooth or crisp. If the widget doesn't have a rendering
+// mode of its own, WithDefaultRendering returns a copy of the widget
+// using the given mode.
+type WidgetWithRendering interface {
+	WithDefaultRendering(rendering string) Widget
+}
+
+// newLayer returns an offscreen context of the same size, and with
+// the same transformation, as dc. Painting to the layer and then
+// compositing it with drawLayer is equivalent to painting to dc
+// directly, but allows the pixels to be manipulated in between.
+func newLayer(dc *gg.Context) *gg.Context {
+	layer := gg.NewContext(dc.Width(), dc.Height())
+
+	// gg doesn't expose the transformation matrix, so recover it
+	// from how points are transformed, and rebuild it on the
+	// layer as translate * rotate * shear * scale.
+	e, f := dc.TransformPoint(0, 0)
+	a, b := dc.TransformPoint(1, 0)
+	c, d := dc.TransformPoint(0, 1)
+	a, b, c, d = a-e, b-f, c-e, d-f
+
+	sx := math.Hypot(a, b)
+	layer.Translate(e, f)
+	if sx == 0 {
+		layer.Scale(0, 0)
+		return layer
+	}
+
+	sy := (a*d - b*c) / sx
+	m := (a*c + b*d) / sx
+	layer.Rotate(math.Atan2(b, a))
+	if sy != 0 {
+		layer.Shear(m/sy, 0)
+	}
+	layer.Scale(sx, sy)
+
+	return layer
+}
+
+// drawLayer composites a layer onto dc using the given blend mode
+// and opacity. The layer is drawn untransformed, since its content
+// was painted with dc's transformation already applied. Clipping of
+// dc is respected.
+func drawLayer(dc *gg.Context, layer *gg.Context, blend string, opacity float64) {
+	src, ok := layer.Image().(*image.RGBA)
+	if !ok {
+		return
+	}
+	dst, ok := dc.Image().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	if (blend != "" && blend != BlendNormal) || opacity < 1 {
+		for y := 0; y < src.Bounds().Dy(); y++ {
+			for x := 0; x < src.Bounds().Dx(); x++ {
+				s := src.RGBAAt(x, y)
+				if s.A == 0 {
+					continue
+				}
+				if blend != "" && blend != BlendNormal {
+					s = blendPixel(blend, dst.RGBAAt(x, y), s)
+				}
+				if opacity < 1 {
+					s = scaleAlpha(s, opacity)
+				}
+				src.SetRGBA(x, y, s)
+			}
+		}
+	}
+
+	dc.Push()
+	dc.Identity()
+
+	// DrawImage replaces, rather than composites over, the
+	// destination when gg believes nothing has been painted yet.
+	// That belief is restored by Pop(), so it can't be trusted.
+	// Filling an empty path marks the context as painted.
+	dc.ClearPath()
+	dc.Fill()
+
+	dc.DrawImage(src, 0, 0)
+	dc.Pop()
+}
+
+// PaintLayer calls paint to draw onto an offscreen layer, passes
+// every painted pixel through recolor, and composites the layer onto
+// dc. Colors passed to and returned by recolor are premultiplied by
+// alpha.
+func PaintLayer(dc *gg.Context, recolor func(color.RGBA) color.RGBA, paint func(dc *gg.Context)) {
+	layer := newLayer(dc)
+	paint(layer)
+
+	if im, ok := layer.Image().(*image.RGBA); ok {
+		for y := 0; y < im.Bounds().Dy(); y++ {
+			for x := 0; x < im.Bounds().Dx(); x++ {
+				if c := im.RGBAAt(x, y); c.A != 0 {
+					im.SetRGBA(x, y, recolor(c))
+				}
+			}
+		}
+	}
+
+	drawLayer(dc, layer, BlendNormal, 1)
+}
+
+// Multiplies the alpha of a premultiplied color by f.
+func scaleAlpha(c color.RGBA, f float64) color.RGBA {
+	f = math.Max(0, math.Min(1, f))
+	return color.RGBA{
+		uint8(math.Round(float64(c.R) * f)),
+		uint8(math.Round(float64(c.G) * f)),
+		uint8(math.Round(float64(c.B) * f)),
+		uint8(math.Round(float64(c.A) * f)),
+	}
+}
+
+// blendPixel recolors the (premultiplied) source pixel s so that
+// compositing it over the backdrop b with the normal "over" operator
+// yields the result of the blend mode. See
+// https://www.w3.org/TR/compositing-1/#blending
+func blendPixel(mode string, b, s color.RGBA) color.RGBA {
+	as := float64(s.A) / 0xff
+	ab := float64(b.A) / 0xff
+
+	unpremul := func(v uint8, a float64) float64 {
+		if a == 0 {
+			return 0
+		}
+		return math.Min(1, float64(v)/0xff/a)
+	}
+
+	channel := func(cb, cs float64) float64 {
+		var mixed float64
+		switch mode {
+		case BlendAdditive:
+			mixed = math.Min(1, cb+cs)
+		case BlendMultiply:
+			mixed = cb * cs
+		case BlendScreen:
+			mixed = cb + cs - cb*cs
+		default:
+			mixed = cs
+		}
+		return (1-ab)*cs + ab*mixed
+	}
+
+	r := channel(unpremul(b.R, ab), unpremul(s.R, as))
+	g := channel(unpremul(b.G, ab), unpremul(s.G, as))
+	bl := channel(unpremul(b.B, ab), unpremul(s.B, as))
+
+	return color.RGBA{
+		uint8(math.Round(r * as * 0xff)),
+		uint8(math.Round(g * as * 0xff)),
+		uint8(math.Round(bl * as * 0xff)),
+		s.A,
+	}
+}
+
+// fillCrisp fills every pixel within bounds whose center is inside
+// the shape, in the current color. Pixels are filled as whole-pixel
+// rectangles, so edges are never anti-aliased.
+func fillCrisp(dc *gg.Context, bounds image.Rectangle, inside func(x, y float64) bool) {
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		start := -1
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			in := x < bounds.Max.X && inside(float64(x)+0.5, float64(y)+0.5)
+			if in && start < 0 {
+				start = x
+			} else if !in && start >= 0 {
+				dc.DrawRectangle(float64(start), float64(y), float64(x-start), 1)
+				start = -1
+			}
+		}
+	}
+	dc.Fill()
+}
+
+// Returns the smallest rectangle of whole pixels covering the area
+// from (x0, y0) to (x1, y1).
+func crispBounds(x0, y0, x1, y1 float64) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(x0)),
+		int(math.Floor(y0)),
+		int(math.Ceil(x1)),
+		int(math.Ceil(y1)),
+	)
+}
+
+// Reports whether (x, y) is inside the rectangle at (x0, y0) of size
+// w by h, with corners rounded to radius r.
+func insideRoundedRect(x, y, x0, y0, w, h, r float64) bool {
+	if x < x0 || y < y0 || x > x0+w || y > y0+h {
+		return false
+	}
+	r = math.Min(r, math.Min(w, h)/2)
+	cx := math.Max(x0+r, math.Min(x, x0+w-r))
+	cy := math.Max(y0+r, math.Min(y, y0+h-r))
+	return math.Hypot(x-cx, y-cy) <= r
+}
+
+// Reports whether (x, y) is inside the ring around (cx, cy) between
+// radii r0 and r1, and between angles a0 and a1 going clockwise, in
+// radians.
+func insideArc(x, y, cx, cy, r0, r1, a0, a1 float64) bool {
+	d := math.Hypot(x-cx, y-cy)
+	if d < r0 || d > r1 {
+		return false
+	}
+
+	if a1 < a0 {
+		a0, a1 = a1, a0
+	}
+	if a1-a0 >= 2*math.Pi {
+		return true
+	}
+	a := math.Mod(math.Atan2(y-cy, x-cx)-a0, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a <= a1-a0
+}
+
+// Reports whether (x, y) is on the segment from (x0, y0) to (x1, y1),
+// stroked w wide. Round segments have round ends, others have square
+// ends reaching w/2 past their points.
+func insideSegment(x, y, x0, y0, x1, y1, w float64, round bool) bool {
+	dx, dy := x1-x0, y1-y0
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		dx, dy = 1, 0
+	} else {
+		dx, dy = dx/length, dy/length
+	}
+
+	// Distance along and across the segment
+	along := (x-x0)*dx + (y-y0)*dy
+	across := -(x-x0)*dy + (y-y0)*dx
+
+	if round {
+		along = math.Max(0, math.Min(length, along))
+		return math.Hypot(x-(x0+along*dx), y-(y0+along*dy)) <= w/2
+	}
+	return along >= -w/2 && along < length+w/2 && across >= -w/2 && across < w/2
+}
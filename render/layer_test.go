@@ -0,0 +1,184 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidbyt/gg"
+)
+
+func TestNewLayerCopiesTransform(t *testing.T) {
+	dc := gg.NewContext(32, 32)
+	dc.Translate(3, 5)
+	dc.Rotate(0.5)
+	dc.Scale(2, -1.5)
+	dc.Shear(0.25, 0)
+
+	layer := newLayer(dc)
+	for _, p := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {7, -3}} {
+		ex, ey := dc.TransformPoint(p[0], p[1])
+		ax, ay := layer.TransformPoint(p[0], p[1])
+		assert.InDelta(t, ex, ax, 1e-9)
+		assert.InDelta(t, ey, ay, 1e-9)
+	}
+}
+
+func TestDrawLayerNormal(t *testing.T) {
+	dc := gg.NewContext(4, 1)
+	dc.SetColor(color.RGBA{0, 0, 0xff, 0xff})
+	dc.Clear()
+
+	// Partially transparent pixels are composited exactly
+	layer := newLayer(dc)
+	layer.SetColor(color.RGBA{0xff, 0, 0, 0xff})
+	layer.SetPixel(1, 0)
+	layer.SetColor(color.RGBA{0x80, 0, 0, 0x80})
+	layer.SetPixel(2, 0)
+
+	drawLayer(dc, layer, BlendNormal, 1)
+
+	im := dc.Image().(*image.RGBA)
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, im.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(1, 0))
+	assert.Equal(t, color.RGBA{0x80, 0, 0x7f, 0xff}, im.RGBAAt(2, 0))
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, im.RGBAAt(3, 0))
+}
+
+func TestBlendPixel(t *testing.T) {
+	backdrop := color.RGBA{0x80, 0x80, 0, 0xff}
+	src := color.RGBA{0x80, 0, 0xff, 0xff}
+
+	assert.Equal(t, color.RGBA{0xff, 0x80, 0xff, 0xff}, blendPixel(BlendAdditive, backdrop, src))
+	assert.Equal(t, color.RGBA{0x40, 0, 0, 0xff}, blendPixel(BlendMultiply, backdrop, src))
+	assert.Equal(t, color.RGBA{0xc0, 0x80, 0xff, 0xff}, blendPixel(BlendScreen, backdrop, src))
+	assert.Equal(t, src, blendPixel(BlendNormal, backdrop, src))
+
+	// Nothing to blend with on a transparent backdrop
+	assert.Equal(t, src, blendPixel(BlendMultiply, color.RGBA{}, src))
+}
+
+func TestCrispCircle(t *testing.T) {
+	c := Circle{Color: color.RGBA{0xff, 0xff, 0xff, 0xff}, Diameter: 7}
+
+	hasPartialAlpha := func(im *image.RGBA) bool {
+		for y := 0; y < 7; y++ {
+			for x := 0; x < 7; x++ {
+				if a := im.RGBAAt(x, y).A; a != 0 && a != 0xff {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	im := PaintWidget(c, image.Rect(0, 0, 10, 10), 0).(*image.RGBA)
+	assert.True(t, hasPartialAlpha(im))
+
+	c.Rendering = RenderingCrisp
+	im = PaintWidget(c, image.Rect(0, 0, 10, 10), 0).(*image.RGBA)
+	assert.False(t, hasPartialAlpha(im))
+	assert.Nil(t, checkImage([]string{
+		"..www..",
+		".wwwww.",
+		"wwwwwww",
+		"wwwwwww",
+		"wwwwwww",
+		".wwwww.",
+		"..www..",
+	}, im.SubImage(image.Rect(0, 0, 7, 7))))
+}
+
+func TestCrispShapesHaveNoPartialAlpha(t *testing.T) {
+	for _, w := range []Widget{
+		Gauge{Diameter: 16, Value: 60, Rendering: RenderingCrisp},
+		Gauge{Diameter: 16, Value: 60, Style: "needle", BackgroundColor: color.White, Rendering: RenderingCrisp},
+		PieChart{Diameter: 9, Weights: []float64{1, 2}, Colors: []color.Color{color.White, color.RGBA{0xff, 0, 0, 0xff}}, Rendering: RenderingCrisp},
+		Line{X1: 0, Y1: 0, X2: 9, Y2: 4, StrokeWidth: 3, Rendering: RenderingCrisp},
+		RoundedBox{Width: 9, Height: 7, Radius: 3, StrokeColor: color.White, StrokeWidth: 2, Rendering: RenderingCrisp},
+	} {
+		im := PaintWidget(w, image.Rect(0, 0, 20, 20), 0).(*image.RGBA)
+		painted := false
+		for i := 3; i < len(im.Pix); i += 4 {
+			assert.True(t, im.Pix[i] == 0 || im.Pix[i] == 0xff, "%T", w)
+			painted = painted || im.Pix[i] != 0
+		}
+		assert.True(t, painted, "%T", w)
+	}
+}
+
+func TestStackBlend(t *testing.T) {
+	s := Stack{
+		Children: []Widget{
+			Box{Width: 2, Height: 1, Color: color.RGBA{0xff, 0, 0, 0xff}},
+			Box{Width: 1, Height: 1, Color: color.RGBA{0, 0, 0xff, 0xff}},
+		},
+	}
+
+	im := PaintWidget(s, image.Rect(0, 0, 2, 1), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0, 0, 0xff, 0xff}, im.RGBAAt(0, 0))
+
+	s.Blend = BlendAdditive
+	im = PaintWidget(s, image.Rect(0, 0, 2, 1), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0xff, 0, 0xff, 0xff}, im.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(1, 0))
+
+	s.Blend = BlendMultiply
+	im = PaintWidget(s, image.Rect(0, 0, 2, 1), 0).(*image.RGBA)
+	assert.Equal(t, color.RGBA{0, 0, 0, 0xff}, im.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, im.RGBAAt(1, 0))
+}
+
+func TestRootRendering(t *testing.T) {
+	inner := &Circle{Color: color.RGBA{0xff, 0xff, 0xff, 0xff}, Diameter: 5}
+	outer := Row{Children: []Widget{
+		Padding{Child: inner},
+		Circle{Color: color.RGBA{0xff, 0xff, 0xff, 0xff}, Diameter: 5, Rendering: RenderingSmooth},
+	}}
+
+	mapped := mapWidgets(outer, func(w Widget) Widget {
+		if wr, ok := w.(WidgetWithRendering); ok {
+			return wr.WithDefaultRendering(RenderingCrisp)
+		}
+		return w
+	}).(Row)
+
+	assert.Equal(t, RenderingCrisp, mapped.Children[0].(Padding).Child.(Circle).Rendering)
+	assert.Equal(t, RenderingSmooth, mapped.Children[1].(Circle).Rendering)
+
+	// The original tree is left untouched
+	assert.Equal(t, "", inner.Rendering)
+
+	r := Root{Child: outer, Rendering: RenderingCrisp}
+	im := r.Paint(false)[0].(*image.RGBA)
+	assert.Equal(t, uint8(0xff), im.RGBAAt(0, 1).A)
+	assert.Equal(t, uint8(0), im.RGBAAt(0, 0).A)
+}
+
+func TestRootInitResolvesDefaults(t *testing.T) {
+	r := &Root{
+		Child:     Row{Children: []Widget{Circle{Diameter: 5}}},
+		Rendering: RenderingCrisp,
+	}
+	assert.NoError(t, r.Init())
+
+	// The rendering mode is passed down once, when the root is built
+	assert.Equal(t, RenderingCrisp, r.Child.(Row).Children[0].(Circle).Rendering)
+	assert.True(t, r.resolved)
+}
+
+func TestInvalidRenderingAndBlend(t *testing.T) {
+	assert.NoError(t, (&Circle{Rendering: RenderingCrisp}).Init())
+	assert.NoError(t, (&Root{}).Init())
+	assert.NoError(t, (&Stack{Blend: BlendMultiply}).Init())
+
+	err := (&Circle{Rendering: "crispy"}).Init()
+	assert.ErrorContains(t, err, "invalid rendering: crispy, must be smooth or crisp")
+
+	err = (&Root{Rendering: "sharp"}).Init()
+	assert.ErrorContains(t, err, "invalid rendering: sharp")
+
+	err = (&Stack{Blend: "multiplyy"}).Init()
+	assert.ErrorContains(t, err, "invalid blend: multiplyy, must be one of normal, additive, multiply or screen")
+}
@@ -0,0 +1,176 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"reflect"
+
+	"github.com/tidbyt/gg"
+)
+
+// WidgetBounds is the area a widget painted in a frame, in canvas
+// pixels. Depth is how deeply the widget is nested under the root's
+// child, which has depth 0.
+type WidgetBounds struct {
+	Type   string
+	Bounds image.Rectangle
+	Depth  int
+}
+
+// WithLayout records the layout of every frame painted into layout,
+// which is indexed by frame. Each frame lists the widgets painted in
+// it, parents before their children, in the order they were painted.
+//
+// Recording the layout makes painting slower, so it's meant for
+// debugging only.
+func WithLayout(layout *[][]WidgetBounds) RootPaintOption {
+	return func(r *Root) {
+		r.layout = layout
+	}
+}
+
+// layoutRecorder collects the layout of a single frame. Frames are
+// painted in a goroutine each, so a recorder is only used by one
+// goroutine.
+type layoutRecorder struct {
+	widgets []WidgetBounds
+	depth   int
+}
+
+// layoutWidget records where its widget is painted, and paints it.
+type layoutWidget struct {
+	Widget
+
+	recorder *layoutRecorder
+}
+
+func (w layoutWidget) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	pb := w.Widget.PaintBounds(bounds, frameIdx)
+
+	w.recorder.widgets = append(w.recorder.widgets, WidgetBounds{
+		Type:   widgetTypeName(w.Widget),
+		Bounds: transformRect(dc, pb),
+		Depth:  w.recorder.depth,
+	})
+
+	w.recorder.depth++
+	w.Widget.Paint(dc, bounds, frameIdx)
+	w.recorder.depth--
+}
+
+// Returns a copy of child where every widget records its layout into
+// recorder.
+func recordLayout(child Widget, recorder *layoutRecorder) Widget {
+	return mapWidgets(child, func(w Widget) Widget {
+		return layoutWidget{w, recorder}
+	})
+}
+
+// Returns the name of a widget's type, such as "Text".
+func widgetTypeName(w Widget) string {
+	t := reflect.TypeOf(w)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Returns the smallest rectangle, in canvas pixels, that covers r once
+// it's been transformed by the current transformation of dc.
+func transformRect(dc *gg.Context, r image.Rectangle) image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+	for _, p := range []image.Point{
+		r.Min,
+		{r.Max.X, r.Min.Y},
+		{r.Min.X, r.Max.Y},
+		r.Max,
+	} {
+		x, y := dc.TransformPoint(float64(p.X), float64(p.Y))
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+
+	return image.Rect(
+		int(math.Floor(minX)),
+		int(math.Floor(minY)),
+		int(math.Ceil(maxX)),
+		int(math.Ceil(maxY)),
+	)
+}
+
+// Outline colors for each level of nesting, repeating for deeper
+// levels.
+var layoutColors = []color.RGBA{
+	{0xff, 0x00, 0xff, 0xff},
+	{0x00, 0xff, 0xff, 0xff},
+	{0xff, 0xff, 0x00, 0xff},
+	{0x00, 0xff, 0x00, 0xff},
+	{0xff, 0x80, 0x00, 0xff},
+	{0x80, 0x80, 0xff, 0xff},
+}
+
+// LayoutColor returns the outline color for widgets at the given
+// depth.
+func LayoutColor(depth int) color.RGBA {
+	return layoutColors[depth%len(layoutColors)]
+}
+
+// PaintLayout magnifies a painted frame by scale, and draws the
+// outline of every widget in layout on top of it, labelled with the
+// widget's type.
+func PaintLayout(frame image.Image, layout []WidgetBounds, scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	// Pixels are magnified into squares, without smoothing
+	fb := frame.Bounds()
+	magnified := image.NewRGBA(image.Rect(0, 0, fb.Dx()*scale, fb.Dy()*scale))
+	for y := 0; y < magnified.Bounds().Dy(); y++ {
+		for x := 0; x < magnified.Bounds().Dx(); x++ {
+			magnified.Set(x, y, frame.At(fb.Min.X+x/scale, fb.Min.Y+y/scale))
+		}
+	}
+
+	dc := gg.NewContextForRGBA(magnified)
+
+	face, _ := GetFont("tom-thumb")
+
+	for _, w := range layout {
+		c := LayoutColor(w.Depth)
+		b := w.Bounds
+
+		dc.SetColor(c)
+		dc.SetLineWidth(1)
+		dc.DrawRectangle(
+			float64(b.Min.X*scale)+0.5,
+			float64(b.Min.Y*scale)+0.5,
+			float64(b.Dx()*scale)-1,
+			float64(b.Dy()*scale)-1,
+		)
+		dc.Stroke()
+
+		if face != nil {
+			// Labels are nudged down by depth, so that nested
+			// widgets sharing a corner stay readable, and put on
+			// a dark background to stand out from what's painted.
+			dc.SetFontFace(face)
+			lw, _ := dc.MeasureString(w.Type)
+			lh := face.Metrics().Height.Ceil()
+			x := float64(b.Min.X*scale + 2)
+			y := float64(b.Min.Y*scale + 2 + (w.Depth%4)*(lh+1))
+
+			dc.SetColor(color.RGBA{0, 0, 0, 0xc0})
+			dc.DrawRectangle(x-1, y, lw+2, float64(lh))
+			dc.Fill()
+
+			dc.SetColor(c)
+			dc.DrawString(w.Type, x, y+float64(face.Metrics().Ascent.Ceil()))
+		}
+	}
+
+	return dc.Image()
+}
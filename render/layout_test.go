@@ -0,0 +1,74 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootLayout(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	root := Root{
+		Child: Padding{
+			Pad: Insets{Left: 2, Top: 1},
+			Child: Row{
+				Children: []Widget{
+					Box{Width: 3, Height: 3, Color: red},
+					Animation{Children: []Widget{
+						Box{Width: 2, Height: 2, Color: blue},
+						Box{Width: 4, Height: 1, Color: blue},
+					}},
+				},
+			},
+		},
+	}
+
+	var layout [][]WidgetBounds
+	frames := root.Paint(true, WithLayout(&layout))
+	require.Equal(t, 2, len(frames))
+	require.Equal(t, 2, len(layout))
+
+	assert.Equal(t, []WidgetBounds{
+		{"Padding", image.Rect(0, 0, 7, 4), 0},
+		{"Row", image.Rect(2, 1, 7, 4), 1},
+		{"Box", image.Rect(2, 1, 5, 4), 2},
+		{"Animation", image.Rect(5, 1, 7, 3), 2},
+		{"Box", image.Rect(5, 1, 7, 3), 3},
+	}, layout[0])
+
+	assert.Equal(t, []WidgetBounds{
+		{"Padding", image.Rect(0, 0, 9, 4), 0},
+		{"Row", image.Rect(2, 1, 9, 4), 1},
+		{"Box", image.Rect(2, 1, 5, 4), 2},
+		{"Animation", image.Rect(5, 1, 9, 2), 2},
+		{"Box", image.Rect(5, 1, 9, 2), 3},
+	}, layout[1])
+
+	// Recording the layout doesn't change what's painted
+	plain := root.Paint(true)
+	assert.Equal(t, plain, frames)
+}
+
+func TestPaintLayout(t *testing.T) {
+	frame := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	layout := []WidgetBounds{
+		{"Box", image.Rect(1, 0, 3, 2), 0},
+	}
+
+	im := PaintLayout(frame, layout, 8)
+	assert.Equal(t, image.Rect(0, 0, 32, 16), im.Bounds())
+
+	// The outline is drawn around the widget, in the color of its
+	// depth
+	r, g, b, _ := im.At(8, 8).RGBA()
+	c := LayoutColor(0)
+	assert.Equal(t, []uint32{uint32(c.R) * 0x101, uint32(c.G) * 0x101, uint32(c.B) * 0x101}, []uint32{r, g, b})
+
+	r, g, b, _ = im.At(0, 8).RGBA()
+	assert.Equal(t, []uint32{0, 0, 0}, []uint32{r, g, b})
+}
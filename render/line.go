@@ -0,0 +1,193 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Line draws a straight line from (`x1`, `y1`) to (`x2`, `y2`).
+//
+// Coordinates are in pixels, relative to the top left corner of the
+// widget, and both end points are included in the line. The widget
+// is made just large enough to hold the line.
+//
+// Lines are drawn crisp, one pixel at a time, unless `rendering` is
+// set to `"smooth"`, in which case they're anti-aliased.
+//
+// DOC(X1): X-coordinate of start point
+// DOC(Y1): Y-coordinate of start point
+// DOC(X2): X-coordinate of end point
+// DOC(Y2): Y-coordinate of end point
+// DOC(Color): Line color, default is '#fff'
+// DOC(StrokeWidth): Line width, default is 1
+// DOC(Rendering): Either "smooth" or "crisp", default is "crisp"
+//
+// EXAMPLE BEGIN
+// render.Column(
+//      children=[
+//           render.Line(x1=0, y1=0, x2=63, y2=15, color="#0af"),
+//           render.Line(x1=0, y1=1, x2=63, y2=1, color="#fa0", stroke_width=3),
+//      ],
+// )
+// EXAMPLE END
+type Line struct {
+	Widget
+
+	X1          int         `starlark:"x1,required"`
+	Y1          int         `starlark:"y1,required"`
+	X2          int         `starlark:"x2,required"`
+	Y2          int         `starlark:"y2,required"`
+	Color       color.Color `starlark:"color"`
+	StrokeWidth int         `starlark:"stroke_width"`
+	Rendering   string      `starlark:"rendering"`
+}
+
+func (l Line) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return pointsBounds([]PathPoint{{l.X1, l.Y1}, {l.X2, l.Y2}}, l.StrokeWidth)
+}
+
+func (l Line) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	col := color.Color(DefaultPlotColor)
+	if l.Color != nil {
+		col = l.Color
+	}
+
+	rendering := l.Rendering
+	if rendering == "" {
+		rendering = RenderingCrisp
+	}
+
+	strokePoints(dc, []PathPoint{{l.X1, l.Y1}, {l.X2, l.Y2}}, false, col, l.StrokeWidth, rendering)
+}
+
+func (l Line) FrameCount() int {
+	return 1
+}
+
+func (l *Line) Init() error {
+	return checkRendering(l.Rendering)
+}
+
+func (l Line) WithDefaultRendering(rendering string) Widget {
+	if l.Rendering == "" {
+		l.Rendering = rendering
+	}
+	return l
+}
+
+// pointsBounds returns the smallest rectangle, anchored at the
+// origin, that holds all points when stroked with the given width.
+func pointsBounds(points []PathPoint, strokeWidth int) image.Rectangle {
+	w, h := 0, 0
+	for _, p := range points {
+		if p.X+1 > w {
+			w = p.X + 1
+		}
+		if p.Y+1 > h {
+			h = p.Y + 1
+		}
+	}
+
+	// Thick lines extend past their points
+	if strokeWidth > 1 && len(points) > 0 {
+		w += strokeWidth / 2
+		h += strokeWidth / 2
+	}
+
+	return image.Rect(0, 0, w, h)
+}
+
+// strokePoints draws lines through a list of pixel coordinates. If
+// closed is set, the last point is
+// connected back to the first.
+//
+// Crisp lines one pixel wide are drawn with Bresenham's algorithm.
+// Anything else is stroked through pixel centers, and filled with
+// whole pixels if crisp.
+func strokePoints(dc *gg.Context, points []PathPoint, closed bool, col color.Color, strokeWidth int, rendering string) {
+	if len(points) == 0 {
+		return
+	}
+	if strokeWidth <= 0 {
+		strokeWidth = 1
+	}
+
+	vertices := points
+	if closed && len(points) > 2 {
+		vertices = append(append([]PathPoint{}, points...), points[0])
+	}
+
+	if rendering == RenderingCrisp && strokeWidth == 1 {
+		if len(vertices) == 1 {
+			vertices = append(vertices, vertices[0])
+		}
+		dc.SetColor(col)
+		pl := &PolyLine{Vertices: vertices}
+		for i := 0; i < pl.Length(); i++ {
+			x, y := pl.Point(i)
+			tx, ty := dc.TransformPoint(float64(x), float64(y))
+			dc.SetPixel(int(tx), int(ty))
+		}
+		return
+	}
+
+	dc.Push()
+	dc.SetColor(col)
+	w := float64(strokeWidth)
+
+	if rendering == RenderingCrisp {
+		// Round ends on thick lines snap to ragged pixels, so
+		// every segment is squared off. A lone point is a
+		// square the size of the pen.
+		if len(vertices) == 1 {
+			vertices = append(vertices, vertices[0])
+		}
+		minX, minY := math.Inf(1), math.Inf(1)
+		maxX, maxY := math.Inf(-1), math.Inf(-1)
+		for _, p := range vertices {
+			minX, maxX = math.Min(minX, float64(p.X)), math.Max(maxX, float64(p.X))
+			minY, maxY = math.Min(minY, float64(p.Y)), math.Max(maxY, float64(p.Y))
+		}
+		area := crispBounds(minX+0.5-w/2, minY+0.5-w/2, maxX+0.5+w/2, maxY+0.5+w/2)
+
+		fillCrisp(dc, area, func(x, y float64) bool {
+			for i := 1; i < len(vertices); i++ {
+				p, q := vertices[i-1], vertices[i]
+				if insideSegment(
+					x, y,
+					float64(p.X)+0.5, float64(p.Y)+0.5,
+					float64(q.X)+0.5, float64(q.Y)+0.5,
+					w, false,
+				) {
+					return true
+				}
+			}
+			return false
+		})
+		dc.Pop()
+		return
+	}
+
+	dc.SetLineWidth(w)
+	dc.SetLineCapRound()
+	dc.SetLineJoinRound()
+
+	if len(vertices) == 1 {
+		// A lone point is drawn as a dot the size of the pen
+		r := w / 2
+		dc.DrawCircle(float64(vertices[0].X)+0.5, float64(vertices[0].Y)+0.5, r)
+		dc.Fill()
+		dc.Pop()
+		return
+	}
+
+	dc.NewSubPath()
+	for _, p := range vertices {
+		dc.LineTo(float64(p.X)+0.5, float64(p.Y)+0.5)
+	}
+	dc.Stroke()
+	dc.Pop()
+}
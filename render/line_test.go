@@ -0,0 +1,64 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidbyt/gg"
+)
+
+func TestLineCrisp(t *testing.T) {
+	l := Line{X1: 0, Y1: 0, X2: 5, Y2: 2}
+	assert.Equal(t, image.Rect(0, 0, 6, 3), l.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+
+	im := PaintWidget(l, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"ww....",
+		"..ww..",
+		"....ww",
+	}, im))
+
+	// Direction doesn't matter
+	l = Line{X1: 3, Y1: 0, X2: 0, Y2: 0, Color: color.RGBA{0xff, 0, 0, 0xff}}
+	im = PaintWidget(l, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{"rrrr"}, im))
+}
+
+func TestLineStrokeWidth(t *testing.T) {
+	l := Line{X1: 1, Y1: 1, X2: 4, Y2: 1, StrokeWidth: 3}
+	assert.Equal(t, image.Rect(0, 0, 6, 3), l.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+
+	im := PaintWidget(l, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"wwwwww",
+		"wwwwww",
+		"wwwwww",
+	}, im))
+}
+
+func TestLineSmooth(t *testing.T) {
+	l := Line{X1: 0, Y1: 0, X2: 7, Y2: 3, Rendering: RenderingSmooth}
+	im := PaintWidget(l, image.Rect(0, 0, 64, 32), 0).(*image.RGBA)
+
+	partial := 0
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			if a := im.RGBAAt(x, y).A; a != 0 && a != 0xff {
+				partial++
+			}
+		}
+	}
+	assert.NotZero(t, partial)
+}
+
+func TestDrawLine(t *testing.T) {
+	l := Line{X1: 0, Y1: 3, X2: 2, Y2: 0}
+	expected := PaintWidget(l, image.Rect(0, 0, 64, 32), 0)
+
+	dc := gg.NewContext(3, 4)
+	dc.SetColor(DefaultPlotColor)
+	DrawLine(dc, 0, 3, 2, 0)
+	assert.Equal(t, expected, dc.Image())
+}
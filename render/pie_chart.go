@@ -12,9 +12,13 @@ import (
 // arguments for the data: parallel lists `colors` and `weights` representing
 // the shading and relative sizes of each data entry.
 //
+// Edges are anti-aliased, unless `rendering` is set to `"crisp"`, in
+// which case they're snapped to whole pixels.
+//
 // DOC(Colors): List of color hex codes
 // DOC(Weights): List of numbers corresponding to the relative size of each color
 // DOC(Diameter): Diameter of the circle
+// DOC(Rendering): Either "smooth" or "crisp", default is "smooth"
 //
 // EXAMPLE BEGIN
 // render.PieChart(
@@ -29,6 +33,8 @@ type PieChart struct {
 	Colors   []color.Color `starlark:"colors, required"`
 	Weights  []float64     `starlark:"weights, required"`
 	Diameter int           `starlark:"diameter,required"`
+
+	Rendering string `starlark:"rendering"`
 }
 
 func (c PieChart) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
@@ -47,10 +53,17 @@ func (c PieChart) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 	for i, v := range c.Weights {
 		end := start + v/total
 		dc.SetColor(c.Colors[i%len(c.Colors)])
-		dc.DrawArc(r, r, r, start*2*math.Pi, end*2*math.Pi)
-		dc.LineTo(r, r)
-		dc.LineTo(r+r*math.Cos(start*2*math.Pi), r+r*math.Sin(start*2*math.Pi))
-		dc.Fill()
+		if c.Rendering == RenderingCrisp {
+			a0, a1 := start*2*math.Pi, end*2*math.Pi
+			fillCrisp(dc, image.Rect(0, 0, c.Diameter, c.Diameter), func(x, y float64) bool {
+				return insideArc(x, y, r, r, 0, r, a0, a1)
+			})
+		} else {
+			dc.DrawArc(r, r, r, start*2*math.Pi, end*2*math.Pi)
+			dc.LineTo(r, r)
+			dc.LineTo(r+r*math.Cos(start*2*math.Pi), r+r*math.Sin(start*2*math.Pi))
+			dc.Fill()
+		}
 		start = end
 	}
 }
@@ -58,3 +71,14 @@ func (c PieChart) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 func (c PieChart) FrameCount() int {
 	return 1
 }
+
+func (c *PieChart) Init() error {
+	return checkRendering(c.Rendering)
+}
+
+func (c PieChart) WithDefaultRendering(rendering string) Widget {
+	if c.Rendering == "" {
+		c.Rendering = rendering
+	}
+	return c
+}
@@ -15,6 +15,9 @@ var FillDampFactor uint8 = 0x55
 
 // Plot is a widget that draws a data series.
 //
+// The line is drawn crisp, one pixel at a time, unless `rendering`
+// is set to `"smooth"`, in which case it's anti-aliased.
+//
 // DOC(Data): A list of 2-tuples of numbers
 // DOC(Width): Limits Plot width
 // DOC(Height): Limits Plot height
@@ -26,6 +29,7 @@ var FillDampFactor uint8 = 0x55
 // DOC(FillColor): Fill color for Y-values above 0
 // DOC(FillColorInverted): Fill color for Y-values below 0
 // DOC(ChartType): Specifies the type of chart to render, "scatter" or "line", default is "line"
+// DOC(Rendering): Either "smooth" or "crisp", default is "crisp"
 //
 // EXAMPLE BEGIN
 // render.Plot(
@@ -84,6 +88,9 @@ type Plot struct {
 	// Optional fill color for Y-values below 0
 	FillColorInverted color.Color `starlark:"fill_color_inverted"`
 
+	// Optional, default "crisp". If set to "smooth", the line is anti-aliased
+	Rendering string `starlark:"rendering"`
+
 	invThreshold int
 }
 
@@ -258,6 +265,25 @@ func (p Plot) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 			}
 			dc.SetPixel(int(point.X), int(point.Y))
 		}
+	} else if p.Rendering == RenderingSmooth {
+		// the line itself, stroked through pixel centers
+		points := p.translatePoints()
+		dc.Push()
+		dc.SetLineWidth(1)
+		dc.SetLineCapRound()
+		for i := 1; i < len(points); i++ {
+			a, b := points[i-1], points[i]
+			if a.Y+b.Y > 2*p.invThreshold {
+				dc.SetColor(colInv)
+			} else {
+				dc.SetColor(col)
+			}
+			dc.NewSubPath()
+			dc.MoveTo(float64(a.X)+0.5, float64(a.Y)+0.5)
+			dc.LineTo(float64(b.X)+0.5, float64(b.Y)+0.5)
+			dc.Stroke()
+		}
+		dc.Pop()
 	} else {
 		// the line itself
 		for i := 0; i < pl.Length(); i++ {
@@ -276,3 +302,14 @@ func (p Plot) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 func (p Plot) FrameCount() int {
 	return 1
 }
+
+func (p *Plot) Init() error {
+	return checkRendering(p.Rendering)
+}
+
+func (p Plot) WithDefaultRendering(rendering string) Widget {
+	if p.Rendering == "" {
+		p.Rendering = rendering
+	}
+	return p
+}
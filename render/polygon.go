@@ -0,0 +1,147 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Polygon draws a closed shape through a list of vertices.
+//
+// Vertices are given as (x, y) pixel coordinates, relative to the
+// top left corner of the widget. The widget is made just large
+// enough to hold the polygon.
+//
+// The polygon is filled with `color`, if provided, and outlined with
+// `stroke_color`, if provided. If neither is given, the polygon is
+// filled with white.
+//
+// Polygons are drawn crisp, with edges on whole pixels, unless
+// `rendering` is set to `"smooth"`, in which case they're
+// anti-aliased.
+//
+// DOC(Vertices): A list of 2-tuples of numbers
+// DOC(Color): Fill color
+// DOC(StrokeColor): Outline color
+// DOC(StrokeWidth): Outline width, default is 1
+// DOC(Rendering): Either "smooth" or "crisp", default is "crisp"
+//
+// EXAMPLE BEGIN
+// render.Row(
+//      expanded=True,
+//      main_align="space_evenly",
+//      children=[
+//           render.Polygon(
+//                vertices=[(10, 0), (20, 20), (0, 20)],
+//                color="#fa0",
+//           ),
+//           render.Polygon(
+//                vertices=[(0, 10), (10, 0), (20, 10), (10, 20)],
+//                stroke_color="#0af",
+//           ),
+//      ],
+// )
+// EXAMPLE END
+type Polygon struct {
+	Widget
+
+	Vertices    [][2]float64 `starlark:"vertices,required"`
+	Color       color.Color  `starlark:"color"`
+	StrokeColor color.Color  `starlark:"stroke_color"`
+	StrokeWidth int          `starlark:"stroke_width"`
+	Rendering   string       `starlark:"rendering"`
+}
+
+// Rounds the vertices to whole pixels.
+func (p Polygon) points() []PathPoint {
+	points := make([]PathPoint, len(p.Vertices))
+	for i, v := range p.Vertices {
+		points[i] = PathPoint{X: int(math.Round(v[0])), Y: int(math.Round(v[1]))}
+	}
+	return points
+}
+
+func (p Polygon) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	strokeWidth := 0
+	if p.StrokeColor != nil {
+		strokeWidth = p.StrokeWidth
+	}
+	return pointsBounds(p.points(), strokeWidth)
+}
+
+func (p Polygon) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	points := p.points()
+	if len(points) == 0 {
+		return
+	}
+
+	rendering := p.Rendering
+	if rendering == "" {
+		rendering = RenderingCrisp
+	}
+
+	fill := p.Color
+	if fill == nil && p.StrokeColor == nil {
+		fill = DefaultPlotColor
+	}
+
+	if fill != nil {
+		dc.SetColor(fill)
+		if rendering == RenderingCrisp {
+			// Fill every pixel with its center inside the
+			// polygon, plus the pixels on its edges.
+			pb := pointsBounds(points, 0)
+			for y := 0; y < pb.Dy(); y++ {
+				for x := 0; x < pb.Dx(); x++ {
+					if insidePolygon(points, float64(x), float64(y)) {
+						tx, ty := dc.TransformPoint(float64(x), float64(y))
+						dc.SetPixel(int(tx), int(ty))
+					}
+				}
+			}
+			strokePoints(dc, points, true, fill, 1, rendering)
+		} else {
+			dc.NewSubPath()
+			for _, pt := range points {
+				dc.LineTo(float64(pt.X)+0.5, float64(pt.Y)+0.5)
+			}
+			dc.ClosePath()
+			dc.Fill()
+		}
+	}
+
+	if p.StrokeColor != nil {
+		strokePoints(dc, points, true, p.StrokeColor, p.StrokeWidth, rendering)
+	}
+}
+
+func (p Polygon) FrameCount() int {
+	return 1
+}
+
+func (p *Polygon) Init() error {
+	return checkRendering(p.Rendering)
+}
+
+func (p Polygon) WithDefaultRendering(rendering string) Widget {
+	if p.Rendering == "" {
+		p.Rendering = rendering
+	}
+	return p
+}
+
+// insidePolygon reports whether (x, y) lies inside the polygon,
+// using the even-odd rule.
+func insidePolygon(points []PathPoint, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		xi, yi := float64(points[i].X), float64(points[i].Y)
+		xj, yj := float64(points[j].X), float64(points[j].Y)
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
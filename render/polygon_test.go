@@ -0,0 +1,55 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolygonFill(t *testing.T) {
+	p := Polygon{Vertices: [][2]float64{{0, 0}, {4, 0}, {4, 4}}}
+	assert.Equal(t, image.Rect(0, 0, 5, 5), p.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+
+	im := PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"wwwww",
+		".wwww",
+		"..www",
+		"...ww",
+		"....w",
+	}, im))
+}
+
+func TestPolygonOutline(t *testing.T) {
+	p := Polygon{
+		Vertices:    [][2]float64{{0, 0}, {4, 0}, {4, 3}, {0, 3}},
+		Color:       color.RGBA{0, 0, 0xff, 0xff},
+		StrokeColor: color.RGBA{0xff, 0, 0, 0xff},
+	}
+
+	im := PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"rrrrr",
+		"rbbbr",
+		"rbbbr",
+		"rrrrr",
+	}, im))
+
+	// Outline only
+	p.Color = nil
+	im = PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"rrrrr",
+		"r...r",
+		"r...r",
+		"rrrrr",
+	}, im))
+}
+
+func TestPolygonEmpty(t *testing.T) {
+	p := Polygon{}
+	assert.Equal(t, image.Rect(0, 0, 0, 0), p.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+	assert.Equal(t, 1, p.FrameCount())
+}
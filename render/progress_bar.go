@@ -0,0 +1,169 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// ProgressBar draws a bar that is filled in proportion to `value`.
+//
+// The bar is filled from left to right, or from bottom to top if
+// `vertical` is set. Its range is given by `min` and `max`, which
+// default to 0 and 100. Values outside the range are clamped.
+//
+// ProgressBars expand to fill all available space, unless `width`
+// and/or `height` is provided.
+//
+// If `segments` is set, the bar is split into that many equally
+// sized segments, separated by `segment_gap` pixels, and only fully
+// reached segments are filled.
+//
+// If `grow_frames` is set, the bar animates from `min` to `value`
+// over that many frames, and then stays filled.
+//
+// DOC(Value): Current value
+// DOC(Min): Value of an empty bar, default is 0
+// DOC(Max): Value of a full bar, default is 100
+// DOC(Width): Limits bar width
+// DOC(Height): Limits bar height
+// DOC(Color): Fill color, default is '#fff'
+// DOC(BackgroundColor): Color of unfilled part of the bar
+// DOC(Vertical): Fill from bottom to top
+// DOC(Segments): Number of segments to split the bar into
+// DOC(SegmentGap): Space between segments
+// DOC(GrowFrames): Number of frames to animate filling the bar
+//
+// EXAMPLE BEGIN
+// render.Column(
+//      expanded=True,
+//      main_align="space_evenly",
+//      children=[
+//           render.ProgressBar(value=70, height=6, color="#0f0", background_color="#030"),
+//           render.ProgressBar(value=3, max=5, height=6, segments=5, segment_gap=1, color="#fa0", background_color="#320"),
+//      ],
+// )
+// EXAMPLE END
+type ProgressBar struct {
+	Widget
+
+	Value           float64     `starlark:"value,required"`
+	Min             float64     `starlark:"min"`
+	Max             *float64    `starlark:"max"`
+	Width           int         `starlark:"width"`
+	Height          int         `starlark:"height"`
+	Color           color.Color `starlark:"color"`
+	BackgroundColor color.Color `starlark:"background_color"`
+	Vertical        bool        `starlark:"vertical"`
+	Segments        int         `starlark:"segments"`
+	SegmentGap      int         `starlark:"segment_gap"`
+	GrowFrames      int         `starlark:"grow_frames"`
+}
+
+// valueFraction maps value onto [0, 1] given a range. If max is nil,
+// it defaults to 100. An empty or inverted range is replaced by
+// [min, min+100].
+func valueFraction(value, min float64, max *float64) float64 {
+	hi := 100.0
+	if max != nil {
+		hi = *max
+	}
+	if hi <= min {
+		hi = min + 100
+	}
+
+	f := (value - min) / (hi - min)
+	if math.IsNaN(f) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, f))
+}
+
+func (p ProgressBar) size(bounds image.Rectangle) (int, int) {
+	w, h := p.Width, p.Height
+	if w == 0 {
+		w = bounds.Dx()
+	}
+	if h == 0 {
+		h = bounds.Dy()
+	}
+	return w, h
+}
+
+func (p ProgressBar) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	w, h := p.size(bounds)
+	return image.Rect(0, 0, w, h)
+}
+
+func (p ProgressBar) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	w, h := p.size(bounds)
+
+	col := color.Color(DefaultPlotColor)
+	if p.Color != nil {
+		col = p.Color
+	}
+
+	fraction := valueFraction(p.Value, p.Min, p.Max) * growProgress(p.GrowFrames, frameIdx)
+
+	// Length of the bar along its main axis
+	length := w
+	if p.Vertical {
+		length = h
+	}
+
+	// Draws the part of the bar from start to end along the main axis
+	fill := func(c color.Color, start, end int) {
+		if end <= start {
+			return
+		}
+		dc.SetColor(c)
+		if p.Vertical {
+			dc.DrawRectangle(0, float64(h-end), float64(w), float64(end-start))
+		} else {
+			dc.DrawRectangle(float64(start), 0, float64(end-start), float64(h))
+		}
+		dc.Fill()
+	}
+
+	if p.Segments <= 1 {
+		filled := int(math.Round(fraction * float64(length)))
+		if p.BackgroundColor != nil {
+			fill(p.BackgroundColor, filled, length)
+		}
+		fill(col, 0, filled)
+		return
+	}
+
+	// Segments share the space left after gaps, with residual
+	// pixels handed out to the first segments.
+	space := length - p.SegmentGap*(p.Segments-1)
+	if space < p.Segments {
+		space = p.Segments
+	}
+	filledSegments := int(math.Floor(fraction*float64(p.Segments) + 1e-9))
+
+	start := 0
+	for i := 0; i < p.Segments; i++ {
+		segLen := space / p.Segments
+		if i < space%p.Segments {
+			segLen++
+		}
+
+		if i < filledSegments {
+			fill(col, start, start+segLen)
+		} else if p.BackgroundColor != nil {
+			fill(p.BackgroundColor, start, start+segLen)
+		}
+
+		start += segLen + p.SegmentGap
+	}
+}
+
+func (p ProgressBar) FrameCount() int {
+	if p.GrowFrames > 1 {
+		return p.GrowFrames
+	}
+	return 1
+}
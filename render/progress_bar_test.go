@@ -0,0 +1,129 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBarHorizontal(t *testing.T) {
+	p := ProgressBar{
+		Value:           40,
+		Width:           10,
+		Height:          2,
+		BackgroundColor: color.RGBA{0, 0, 0xff, 0xff},
+	}
+
+	// Default range is 0 to 100
+	im := PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwbbbbbb",
+		"wwwwbbbbbb",
+	}, im))
+
+	// Values are clamped to the range
+	p.Value = 7
+	p.Min = 2
+	p.Max = floatPtr(4)
+	im = PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwwwwwww",
+		"wwwwwwwwww",
+	}, im))
+}
+
+func TestProgressBarMinWithoutMax(t *testing.T) {
+	// Max is 100 whatever the min, unless it's set
+	p := ProgressBar{
+		Value:  45,
+		Min:    -10,
+		Width:  11,
+		Height: 1,
+	}
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwww.....",
+	}, PaintWidget(p, image.Rect(0, 0, 64, 32), 0)))
+
+	p.Min = 20
+	assert.Equal(t, nil, checkImage([]string{
+		"www........",
+	}, PaintWidget(p, image.Rect(0, 0, 64, 32), 0)))
+
+	// A min above the default max gives the range [min, min+100]
+	p.Min = 120
+	p.Value = 170
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwww.....",
+	}, PaintWidget(p, image.Rect(0, 0, 64, 32), 0)))
+}
+
+func TestProgressBarExpands(t *testing.T) {
+	p := ProgressBar{Value: 50, Height: 1}
+	assert.Equal(t, image.Rect(0, 0, 6, 1), p.PaintBounds(image.Rect(0, 0, 6, 4), 0))
+
+	im := PaintWidget(p, image.Rect(0, 0, 6, 4), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"www...",
+	}, im))
+}
+
+func TestProgressBarVertical(t *testing.T) {
+	p := ProgressBar{
+		Value:    0.5,
+		Max:      floatPtr(1),
+		Width:    2,
+		Height:   4,
+		Vertical: true,
+		Color:    color.RGBA{0xff, 0, 0, 0xff},
+	}
+
+	im := PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"..",
+		"..",
+		"rr",
+		"rr",
+	}, im))
+}
+
+func TestProgressBarSegments(t *testing.T) {
+	p := ProgressBar{
+		Value:           2.9,
+		Max:             floatPtr(4),
+		Width:           12,
+		Height:          1,
+		Segments:        4,
+		SegmentGap:      1,
+		BackgroundColor: color.RGBA{0, 0, 0xff, 0xff},
+	}
+
+	// 9 pixels shared by 4 segments. Only fully reached segments
+	// are filled.
+	im := PaintWidget(p, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"www.ww.bb.bb",
+	}, im))
+}
+
+func TestProgressBarGrowFrames(t *testing.T) {
+	p := ProgressBar{
+		Value:      100,
+		Width:      8,
+		Height:     1,
+		GrowFrames: 3,
+	}
+
+	assert.Equal(t, 3, p.FrameCount())
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwww..",
+	}, PaintWidget(p, image.Rect(0, 0, 64, 32), 0)))
+	assert.Equal(t, nil, checkImage([]string{
+		"wwwwwwww",
+	}, PaintWidget(p, image.Rect(0, 0, 64, 32), 2)))
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
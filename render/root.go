@@ -7,7 +7,6 @@ import (
 	"sync"
 
 	"github.com/tidbyt/gg"
-	"tidbyt.dev/pixlet/globals"
 )
 
 const (
@@ -19,8 +18,15 @@ const (
 
 	// DefaultMaxFrameCount is the default maximum number of frames to render.
 	DefaultMaxFrameCount = 2000
+
+	// DefaultDelay is the frame delay, in milliseconds, used when a
+	// Root doesn't set one.
+	DefaultDelay = 50
 )
 
+// FrameWidth and FrameHeight are the size of the display rendered
+// for. They're set once at startup, and must not change while frames
+// are being painted.
 var FrameWidth = DefaultFrameWidth
 var FrameHeight = DefaultFrameHeight
 
@@ -38,18 +44,66 @@ var FrameHeight = DefaultFrameHeight
 // an expiration time in seconds. Display devices use this to avoid
 // displaying stale data in the event of e.g. connectivity issues.
 //
+// Pass _rendering_ to pick how shapes in the tree are drawn, either
+// `"smooth"` (anti-aliased) or `"crisp"` (snapped to whole pixels).
+// Widgets that set their own rendering mode are left as they are.
+//
+// When an app returns several roots, they're shown one after the
+// other. Pass _transition_ to move to this root from the one before
+// it with an effect, such as `animation.Transition("fade")`.
+//
 // DOC(Child): Widget to render
 // DOC(Delay): Frame delay in milliseconds
 // DOC(MaxAge): Expiration time in seconds
 // DOC(ShowFullAnimation): Request animation is shown in full, regardless of app cycle speed
+// DOC(Rendering): Default rendering mode for shapes, "smooth" or "crisp"
+// DOC(Transition): Transition from the previous root
 type Root struct {
-	Child             Widget `starlark:"child,required"`
-	Delay             int32  `starlark:"delay"`
-	MaxAge            int32  `starlark:"max_age"`
-	ShowFullAnimation bool   `starlark:"show_full_animation"`
+	Child             Widget     `starlark:"child,required"`
+	Delay             int32      `starlark:"delay"`
+	MaxAge            int32      `starlark:"max_age"`
+	ShowFullAnimation bool       `starlark:"show_full_animation"`
+	Rendering         string     `starlark:"rendering"`
+	Transition        Transition `starlark:"transition"`
 
 	maxParallelFrames int
 	maxFrameCount     int
+	layout            *[][]WidgetBounds
+	resolved          bool
+}
+
+func (r *Root) Init() error {
+	if err := checkRendering(r.Rendering); err != nil {
+		return err
+	}
+
+	r.resolveDefaults()
+	return nil
+}
+
+// Passes the rendering mode and frame delay of the root down to the
+// widgets in its tree that take them. This is done once, when the
+// root is built, or else when it's first painted.
+func (r *Root) resolveDefaults() {
+	if r.resolved {
+		return
+	}
+
+	delay := int(r.Delay)
+	if delay <= 0 {
+		delay = DefaultDelay
+	}
+
+	r.Child = mapWidgets(r.Child, func(w Widget) Widget {
+		if wr, ok := w.(WidgetWithRendering); ok && r.Rendering != "" {
+			w = wr.WithDefaultRendering(r.Rendering)
+		}
+		if wd, ok := w.(WidgetWithFrameDelay); ok {
+			w = wd.WithFrameDelay(delay)
+		}
+		return w
+	})
+	r.resolved = true
 }
 
 type RootPaintOption func(*Root)
@@ -74,36 +128,55 @@ func WithMaxFrameCount(max int) RootPaintOption {
 	}
 }
 
-// Paint renders the child widget onto the frame. It doesn't do
-// any resizing or alignment.
-func (r Root) Paint(solidBackground bool, opts ...RootPaintOption) []image.Image {
+// Applies the paint options, and prepares the child for painting.
+// Returns the number of frames to paint.
+func (r *Root) prepare(opts ...RootPaintOption) int {
 	for _, opt := range opts {
-		opt(&r)
+		opt(r)
 	}
 
 	if r.maxFrameCount <= 0 {
 		r.maxFrameCount = DefaultMaxFrameCount
 	}
 
+	r.resolveDefaults()
+
 	numFrames := r.Child.FrameCount()
 	if numFrames > r.maxFrameCount {
 		numFrames = r.maxFrameCount
 	}
 
+	return numFrames
+}
+
+// PaintBounds returns the area the child widget paints in each frame,
+// for the same frames that Paint renders.
+func (r Root) PaintBounds(opts ...RootPaintOption) []image.Rectangle {
+	numFrames := r.prepare(opts...)
+
+	bounds := make([]image.Rectangle, numFrames)
+	for i := range bounds {
+		bounds[i] = r.Child.PaintBounds(image.Rect(0, 0, FrameWidth, FrameHeight), i)
+	}
+
+	return bounds
+}
+
+// Paint renders the child widget onto the frame. It doesn't do
+// any resizing or alignment.
+func (r Root) Paint(solidBackground bool, opts ...RootPaintOption) []image.Image {
+	numFrames := r.prepare(opts...)
+
 	frames := make([]image.Image, numFrames)
+	if r.layout != nil {
+		*r.layout = make([][]WidgetBounds, numFrames)
+	}
 
 	parallelism := r.maxParallelFrames
 	if parallelism <= 0 {
 		parallelism = runtime.NumCPU()
 	}
 
-	if globals.Width != DefaultFrameWidth {
-		FrameWidth = globals.Width
-	}
-	if globals.Height != DefaultFrameHeight {
-		FrameHeight = globals.Height
-	}
-
 	var wg sync.WaitGroup
 	sem := make(chan bool, parallelism)
 	for i := 0; i < numFrames; i++ {
@@ -122,10 +195,21 @@ func (r Root) Paint(solidBackground bool, opts ...RootPaintOption) []image.Image
 				dc.Clear()
 			}
 
+			child := r.Child
+			var recorder *layoutRecorder
+			if r.layout != nil {
+				recorder = &layoutRecorder{}
+				child = recordLayout(child, recorder)
+			}
+
 			dc.Push()
-			r.Child.Paint(dc, image.Rect(0, 0, FrameWidth, FrameHeight), i)
+			child.Paint(dc, image.Rect(0, 0, FrameWidth, FrameHeight), i)
 			dc.Pop()
 			frames[i] = dc.Image()
+
+			if recorder != nil {
+				(*r.layout)[i] = recorder.widgets
+			}
 		}(i)
 	}
 
@@ -0,0 +1,169 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// RoundedBox is a Box with rounded corners and an optional outline.
+//
+// Just like a Box, a RoundedBox expands to fill all available space,
+// unless `width` and/or `height` is provided, and its `child` is
+// centered in the box. The corners are rounded with the given
+// `radius`, which is capped at half the width or height.
+//
+// If `stroke_color` is provided, the box is outlined with a border
+// `stroke_width` pixels wide, drawn on the inside of the box.
+//
+// Corners are anti-aliased, unless `rendering` is set to `"crisp"`,
+// in which case they're snapped to whole pixels.
+//
+// DOC(Child): Child to center inside box
+// DOC(Width): Limits box width
+// DOC(Height): Limits box height
+// DOC(Radius): Radius of the corners
+// DOC(Padding): Padding around the child widget
+// DOC(Color): Background color
+// DOC(StrokeColor): Border color
+// DOC(StrokeWidth): Border width, default is 1
+// DOC(Rendering): Either "smooth" or "crisp", default is "smooth"
+//
+// EXAMPLE BEGIN
+// render.RoundedBox(
+//      width=50,
+//      height=20,
+//      radius=6,
+//      color="#123",
+//      stroke_color="#0af",
+//      child=render.Text("hi there"),
+// )
+// EXAMPLE END
+type RoundedBox struct {
+	Widget
+
+	Child       Widget
+	Width       int         `starlark:"width"`
+	Height      int         `starlark:"height"`
+	Radius      int         `starlark:"radius"`
+	Padding     int         `starlark:"padding"`
+	Color       color.Color `starlark:"color"`
+	StrokeColor color.Color `starlark:"stroke_color"`
+	StrokeWidth int         `starlark:"stroke_width"`
+	Rendering   string      `starlark:"rendering"`
+}
+
+func (b RoundedBox) size(bounds image.Rectangle) (int, int) {
+	w, h := b.Width, b.Height
+	if w == 0 {
+		w = bounds.Dx()
+	}
+	if h == 0 {
+		h = bounds.Dy()
+	}
+	return w, h
+}
+
+func (b RoundedBox) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	w, h := b.size(bounds)
+	return image.Rect(0, 0, w, h)
+}
+
+func (b RoundedBox) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	w, h := b.size(bounds)
+	radius := math.Min(float64(b.Radius), math.Min(float64(w), float64(h))/2)
+
+	strokeWidth := b.StrokeWidth
+	if strokeWidth <= 0 {
+		strokeWidth = 1
+	}
+
+	if b.Rendering == RenderingCrisp {
+		fw, fh, sw := float64(w), float64(h), float64(strokeWidth)
+		if b.Color != nil {
+			dc.SetColor(b.Color)
+			fillCrisp(dc, image.Rect(0, 0, w, h), func(x, y float64) bool {
+				return insideRoundedRect(x, y, 0, 0, fw, fh, radius)
+			})
+		}
+		if b.StrokeColor != nil {
+			dc.SetColor(b.StrokeColor)
+			fillCrisp(dc, image.Rect(0, 0, w, h), func(x, y float64) bool {
+				return insideRoundedRect(x, y, 0, 0, fw, fh, radius) &&
+					!insideRoundedRect(x, y, sw, sw, fw-2*sw, fh-2*sw, math.Max(0, radius-sw))
+			})
+		}
+	} else {
+		if b.Color != nil {
+			dc.SetColor(b.Color)
+			dc.DrawRoundedRectangle(0, 0, float64(w), float64(h), radius)
+			dc.Fill()
+		}
+
+		if b.StrokeColor != nil {
+			// Stroke along a path inset by half the border, so
+			// that the border stays inside the box.
+			inset := float64(strokeWidth) / 2
+			dc.Push()
+			dc.SetColor(b.StrokeColor)
+			dc.SetLineWidth(float64(strokeWidth))
+			dc.DrawRoundedRectangle(
+				inset,
+				inset,
+				float64(w)-2*inset,
+				float64(h)-2*inset,
+				math.Max(0, radius-inset),
+			)
+			dc.Stroke()
+			dc.Pop()
+		}
+	}
+
+	if b.Child != nil {
+		chW := w - b.Padding*2
+		chH := h - b.Padding*2
+		if chW < 0 || chH < 0 {
+			// padding makes the child invisible
+			return
+		}
+
+		dc.Push()
+
+		dc.DrawRectangle(
+			float64(b.Padding),
+			float64(b.Padding),
+			float64(chW),
+			float64(chH),
+		)
+		dc.Clip()
+
+		// Same centering as Box
+		childBounds := b.Child.PaintBounds(image.Rect(0, 0, chW, chH), frameIdx)
+		x := w/2 - int(0.5*float64(childBounds.Size().X))
+		y := h/2 - int(0.5*float64(childBounds.Size().Y))
+
+		dc.Translate(float64(x), float64(y))
+		b.Child.Paint(dc, image.Rect(0, 0, chW, chH), frameIdx)
+		dc.Pop()
+	}
+}
+
+func (b RoundedBox) FrameCount() int {
+	if b.Child != nil {
+		return b.Child.FrameCount()
+	}
+	return 1
+}
+
+func (b *RoundedBox) Init() error {
+	return checkRendering(b.Rendering)
+}
+
+func (b RoundedBox) WithDefaultRendering(rendering string) Widget {
+	if b.Rendering == "" {
+		b.Rendering = rendering
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundedBoxCrisp(t *testing.T) {
+	b := RoundedBox{
+		Width:     6,
+		Height:    5,
+		Radius:    2,
+		Color:     color.RGBA{0xff, 0, 0, 0xff},
+		Rendering: RenderingCrisp,
+	}
+	assert.Equal(t, image.Rect(0, 0, 6, 5), b.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+
+	im := PaintWidget(b, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		".rrrr.",
+		"rrrrrr",
+		"rrrrrr",
+		"rrrrrr",
+		".rrrr.",
+	}, im))
+}
+
+func TestRoundedBoxStrokeAndChild(t *testing.T) {
+	b := RoundedBox{
+		Width:       5,
+		Height:      5,
+		StrokeColor: color.RGBA{0xff, 0, 0, 0xff},
+		Child:       Box{Width: 1, Height: 1, Color: color.RGBA{0, 0xff, 0, 0xff}},
+	}
+
+	im := PaintWidget(b, image.Rect(0, 0, 64, 32), 0)
+	assert.Nil(t, checkImage([]string{
+		"rrrrr",
+		"r...r",
+		"r.g.r",
+		"r...r",
+		"rrrrr",
+	}, im))
+
+	// Expands to fill bounds without width and height
+	b.Width, b.Height = 0, 0
+	assert.Equal(t, image.Rect(0, 0, 64, 32), b.PaintBounds(image.Rect(0, 0, 64, 32), 0))
+}
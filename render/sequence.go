@@ -16,7 +16,13 @@ import (
 // If you want to know more about that, go check
 // out the [animation](animation.md) documentation.
 //
+// Pass a _transition_ to move from each child to the next with an
+// effect such as `animation.Transition("push")`, rather than cutting
+// straight to it. The transition adds its own frames between the
+// children.
+//
 // DOC(Children): List of child widgets
+// DOC(Transition): Transition between children
 //
 // EXAMPLE BEGIN
 // render.Sequence(
@@ -25,19 +31,29 @@ import (
 //     animation.Transformation(...),
 //     ...
 //   ],
+//   transition = animation.Transition("slide", duration = 10),
 // ),
 // EXAMPLE END
 type Sequence struct {
 	Widget
 
-	Children []Widget `starlark:"children,required"`
+	Children   []Widget   `starlark:"children,required"`
+	Transition Transition `starlark:"transition"`
+}
+
+// Returns the number of frames the transition after child i lasts.
+func (s Sequence) transitionFrames(i int) int {
+	if s.Transition == nil || i >= len(s.Children)-1 {
+		return 0
+	}
+	return s.Transition.FrameCount()
 }
 
 func (s Sequence) FrameCount() int {
 	fc := 0
 
-	for _, c := range s.Children {
-		fc += c.FrameCount()
+	for i, c := range s.Children {
+		fc += c.FrameCount() + s.transitionFrames(i)
 	}
 
 	return fc
@@ -46,21 +62,40 @@ func (s Sequence) FrameCount() int {
 func (s Sequence) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
 	fc := 0
 
-	for _, c := range s.Children {
+	for i, c := range s.Children {
 		if frameIdx < fc+c.FrameCount() {
 			return c.PaintBounds(bounds, frameIdx-fc)
 		}
 
 		fc += c.FrameCount()
+
+		if frameIdx < fc+s.transitionFrames(i) {
+			return s.transitionBounds(bounds, i)
+		}
+
+		fc += s.transitionFrames(i)
 	}
 
 	return image.Rect(0, 0, 0, 0)
 }
 
+// Transitions cover both the child before and the child after them.
+func (s Sequence) transitionBounds(bounds image.Rectangle, i int) image.Rectangle {
+	from, to := s.Children[i], s.Children[i+1]
+	fb := from.PaintBounds(bounds, from.FrameCount()-1)
+	tb := to.PaintBounds(bounds, 0)
+
+	return image.Rect(
+		0, 0,
+		max(fb.Dx(), tb.Dx()),
+		max(fb.Dy(), tb.Dy()),
+	)
+}
+
 func (s Sequence) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 	fc := 0
 
-	for _, c := range s.Children {
+	for i, c := range s.Children {
 		if frameIdx < fc+c.FrameCount() {
 			dc.Push()
 			c.Paint(dc, bounds, frameIdx-fc)
@@ -69,5 +104,22 @@ func (s Sequence) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 		}
 
 		fc += c.FrameCount()
+
+		if frameIdx < fc+s.transitionFrames(i) {
+			next := s.Children[i+1]
+
+			dc.Push()
+			s.Transition.PaintTransition(
+				dc,
+				s.transitionBounds(bounds, i),
+				still{child: c, frame: c.FrameCount() - 1},
+				still{child: next, frame: 0},
+				frameIdx-fc,
+			)
+			dc.Pop()
+			break
+		}
+
+		fc += s.transitionFrames(i)
 	}
 }
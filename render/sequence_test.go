@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tidbyt/gg"
 )
 
 func TestSequenceOnlyOneFrameAtATime(t *testing.T) {
@@ -164,3 +165,62 @@ func TestSequenceWithAnimatedChildren(t *testing.T) {
 		assert.Equal(t, nil, checkImage(expected[i], im))
 	}
 }
+
+// Cuts from one widget to the next halfway through.
+type cutTransition struct {
+	frames int
+}
+
+func (c cutTransition) FrameCount() int {
+	return c.frames
+}
+
+func (c cutTransition) PaintTransition(dc *gg.Context, bounds image.Rectangle, from, to Widget, frameIdx int) {
+	if frameIdx < c.frames/2 {
+		from.Paint(dc, bounds, frameIdx)
+	} else {
+		to.Paint(dc, bounds, frameIdx)
+	}
+}
+
+func TestSequenceWithTransition(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	green := color.RGBA{0, 0xff, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	seq := Sequence{
+		Children: []Widget{
+			Animation{
+				Children: []Widget{
+					Box{Width: 2, Height: 1, Color: red},
+					Box{Width: 2, Height: 1, Color: green},
+				},
+			},
+			Animation{
+				Children: []Widget{
+					Box{Width: 3, Height: 1, Color: blue},
+					Box{Width: 3, Height: 1, Color: red},
+				},
+			},
+		},
+		Transition: cutTransition{frames: 2},
+	}
+
+	// Transitions are added between children, but not after the last
+	assert.Equal(t, 6, seq.FrameCount())
+
+	expected := [][]string{
+		{"rr"},
+		{"gg"},
+		// The outgoing child stays on its last frame, and the
+		// incoming child on its first
+		{"gg."},
+		{"bbb"},
+		{"bbb"},
+		{"rrr"},
+	}
+	for i, exp := range expected {
+		im := PaintWidget(seq, image.Rect(0, 0, 10, 1), i)
+		assert.Equal(t, nil, checkImage(exp, im), "frame %d", i)
+	}
+}
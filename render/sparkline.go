@@ -0,0 +1,119 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tidbyt/gg"
+)
+
+// Sparkline draws a tiny line chart of a series of values.
+//
+// Values are spread evenly across the width of the sparkline, and
+// the Y-axis is scaled to fit the smallest and largest value. If
+// `fill_color` is set, the area below the line is filled.
+//
+// If `grow_frames` is set, the line is drawn from left to right over
+// that many frames, and then remains fully drawn.
+//
+// DOC(Data): List of values to plot
+// DOC(Width): Width of the sparkline
+// DOC(Height): Height of the sparkline
+// DOC(Color): Line color, default is '#fff'
+// DOC(FillColor): Color of area below the line
+// DOC(GrowFrames): Number of frames to animate line drawing
+//
+// EXAMPLE BEGIN
+// render.Sparkline(
+//      data=[3, 5, 4, 6, 8, 7, 9, 6, 5, 7, 10, 12, 11],
+//      width=64,
+//      height=16,
+//      color="#0f0",
+//      fill_color="#030",
+// )
+// EXAMPLE END
+type Sparkline struct {
+	Widget
+
+	Data       []float64   `starlark:"data,required"`
+	Width      int         `starlark:"width,required"`
+	Height     int         `starlark:"height,required"`
+	Color      color.Color `starlark:"color"`
+	FillColor  color.Color `starlark:"fill_color"`
+	GrowFrames int         `starlark:"grow_frames"`
+}
+
+// Maps the values onto canvas positions, reusing the limit
+// computation of Plot.
+func (s Sparkline) points() []PathPoint {
+	data := make([][2]float64, len(s.Data))
+	for i, v := range s.Data {
+		data[i] = [2]float64{float64(i), v}
+	}
+
+	p := &Plot{
+		Data:   data,
+		Width:  s.Width,
+		Height: s.Height,
+		XLim:   [2]float64{math.NaN(), math.NaN()},
+		YLim:   [2]float64{math.NaN(), math.NaN()},
+	}
+	return p.translatePoints()
+}
+
+func (s Sparkline) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return image.Rect(0, 0, s.Width, s.Height)
+}
+
+func (s Sparkline) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	if len(s.Data) == 0 {
+		return
+	}
+
+	col := color.Color(DefaultPlotColor)
+	if s.Color != nil {
+		col = s.Color
+	}
+
+	// Only columns left of this are drawn
+	maxX := int(math.Ceil(growProgress(s.GrowFrames, frameIdx) * float64(s.Width)))
+
+	// A single value is drawn as a single point
+	vertices := s.points()
+	if len(vertices) == 1 {
+		vertices = append(vertices, vertices[0])
+	}
+	pl := &PolyLine{Vertices: vertices}
+
+	if s.FillColor != nil {
+		dc.SetColor(s.FillColor)
+		for i := 0; i < pl.Length(); i++ {
+			x, y := pl.Point(i)
+			if x >= maxX {
+				continue
+			}
+			for fy := y + 1; fy < s.Height; fy++ {
+				tx, ty := dc.TransformPoint(float64(x), float64(fy))
+				dc.SetPixel(int(tx), int(ty))
+			}
+		}
+	}
+
+	dc.SetColor(col)
+	for i := 0; i < pl.Length(); i++ {
+		x, y := pl.Point(i)
+		if x >= maxX {
+			continue
+		}
+		tx, ty := dc.TransformPoint(float64(x), float64(y))
+		dc.SetPixel(int(tx), int(ty))
+	}
+}
+
+func (s Sparkline) FrameCount() int {
+	if s.GrowFrames > 1 {
+		return s.GrowFrames
+	}
+	return 1
+}
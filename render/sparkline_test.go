@@ -0,0 +1,64 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	s := Sparkline{
+		Data:   []float64{0, 1, 2, 1, 0},
+		Width:  5,
+		Height: 3,
+	}
+
+	im := PaintWidget(s, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"..w..",
+		".w.w.",
+		"w...w",
+	}, im))
+
+	s.FillColor = color.RGBA{0, 0, 0xff, 0xff}
+	im = PaintWidget(s, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"..w..",
+		".wbw.",
+		"wbbbw",
+	}, im))
+}
+
+func TestSparklineSingleValue(t *testing.T) {
+	s := Sparkline{
+		Data:   []float64{7},
+		Width:  3,
+		Height: 3,
+	}
+
+	im := PaintWidget(s, image.Rect(0, 0, 64, 32), 0)
+	assert.Equal(t, nil, checkImage([]string{
+		"...",
+		"w..",
+		"...",
+	}, im))
+}
+
+func TestSparklineGrowFrames(t *testing.T) {
+	s := Sparkline{
+		Data:       []float64{0, 0, 0, 0},
+		Width:      4,
+		Height:     1,
+		GrowFrames: 4,
+	}
+
+	assert.Equal(t, 4, s.FrameCount())
+	assert.Equal(t, nil, checkImage([]string{
+		"www.",
+	}, PaintWidget(s, image.Rect(0, 0, 64, 32), 0)))
+	assert.Equal(t, nil, checkImage([]string{
+		"wwww",
+	}, PaintWidget(s, image.Rect(0, 0, 64, 32), 3)))
+}
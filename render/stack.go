@@ -12,7 +12,13 @@ import (
 // pancakes. The Stack will be given a width and height sufficient to
 // fit all its children.
 //
+// Each child is normally painted over the ones below it. With
+// `blend` set to `"additive"`, `"multiply"` or `"screen"`, children
+// are instead blended with what's below them, which can be used for
+// glow and overlay effects.
+//
 // DOC(Children): Widgets to stack
+// DOC(Blend): How children are blended, default is "normal"
 //
 // EXAMPLE BEGIN
 // render.Stack(
@@ -28,6 +34,11 @@ import (
 type Stack struct {
 	Widget
 	Children []Widget `starlark:"children,required"`
+	Blend    string   `starlark:"blend"`
+}
+
+func (s *Stack) Init() error {
+	return checkBlend(s.Blend)
 }
 
 func (s Stack) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
@@ -55,9 +66,16 @@ func (s Stack) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle
 
 func (s Stack) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
 	for _, child := range s.Children {
-		dc.Push()
-		child.Paint(dc, bounds, frameIdx)
-		dc.Pop()
+		if s.Blend == "" || s.Blend == BlendNormal {
+			dc.Push()
+			child.Paint(dc, bounds, frameIdx)
+			dc.Pop()
+			continue
+		}
+
+		layer := newLayer(dc)
+		child.Paint(layer, bounds, frameIdx)
+		drawLayer(dc, layer, s.Blend, 1)
 	}
 }
 
@@ -1,15 +1,19 @@
 package render
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"math"
 	"math/rand"
+	"sync"
 
 	"github.com/tidbyt/gg"
 )
 
+// Starfield paints stars flying towards the viewer.
+//
+// Stars are placed at random, starting from Seed if it's set.
+// Starfields with the same seed paint the same stars.
 type Starfield struct {
 	Widget
 
@@ -17,7 +21,10 @@ type Starfield struct {
 	Color  color.Color
 	Width  int
 	Height int
-	stars  []*Star
+	Seed   int64
+
+	prepare sync.Once
+	frames  [][]starState
 }
 
 type Star struct {
@@ -37,82 +44,71 @@ func (s *Star) Tick() {
 	s.D *= 1 - s.V
 }
 
-// TODO: use PolyLine from path.go instead
+// DrawLine sets every pixel on the line from (x0, y0) to (x1, y1),
+// both ends included, in the context's current color.
 func DrawLine(dc *gg.Context, x0, y0, x1, y1 int) {
-	dx := x1 - x0
-	if dx < 0 {
-		dx = -dx
-	}
-
-	sx := -1
-	if x0 < x1 {
-		sx = 1
-	}
-
-	dy := y1 - y0
-	if dy > 0 {
-		dy = -dy
+	pl := &PolyLine{Vertices: []PathPoint{{x0, y0}, {x1, y1}}}
+	for i := 0; i < pl.Length(); i++ {
+		dc.SetPixel(pl.Point(i))
 	}
+}
 
-	sy := -1
-	if y0 < y1 {
-		sy = 1
-	}
+// Number of stars in a starfield, and of frames in its animation.
+const (
+	starfieldStars  = 60
+	starfieldFrames = 300
+)
 
-	if dx == 0 {
-		for ; y0 != y1; y0 += sy {
-			dc.SetPixel(x0, y0)
-		}
-		dc.SetPixel(x0, y0)
-		return
-	}
+// Where a star is in a frame, and whether it's shown.
+type starState struct {
+	Star
+	shown bool
+}
 
-	if dy == 0 {
-		for ; x0 != x1; x0 += sx {
-			dc.SetPixel(x0, y0)
-		}
-		dc.SetPixel(x0, y0)
-		return
+// Moves the stars through every frame of the animation, once, so that
+// frames can be painted in any order.
+func (s *Starfield) prepareFrames() {
+	seed := s.Seed
+	if seed == 0 {
+		seed = rand.Int63()
 	}
-
-	err := dx + dy
-
-	dc.SetPixel(x0, y0)
-	for x0 != x1 && y0 != y1 {
-		e2 := 2 * err
-		if e2 >= dy {
-			err += dy
-			x0 += sx
-		}
-		if e2 <= dx {
-			err += dx
-			y0 += sy
+	rng := rand.New(rand.NewSource(seed))
+
+	stars := make([]Star, starfieldStars)
+	for i := range stars {
+		stars[i] = Star{
+			X: 2*rng.Float64() - 1,
+			Y: 2*rng.Float64() - 1,
+			D: 1.0,
+			V: 0.01,
 		}
-		dc.SetPixel(x0, y0)
 	}
-}
-
-func (s *Starfield) Init() error {
-	s.initStars(60)
-	return nil
-}
 
-func (s *Starfield) initStars(numStars int) {
-	s.stars = make([]*Star, numStars)
-	for i := 0; i < numStars; i++ {
-		s.stars[i] = &Star{
-			X: 2*rand.Float64() - 1,
-			Y: 2*rand.Float64() - 1,
-			D: 1.0,  //rand.Float64(),
-			V: 0.01, //rand.Float64()
-		}
-		if i < 10 {
-			fmt.Println(s.stars[i].X, s.stars[i].Y)
+	s.frames = make([][]starState, starfieldFrames)
+	for f := range s.frames {
+		s.frames[f] = make([]starState, len(stars))
+		for j := range stars {
+			star := &stars[j]
+			star.Tick()
+			shown := true
+
+			// Stars that fly out of view start over near the
+			// middle
+			if math.Abs(star.X) > 1.0 || math.Abs(star.Y) > 1.0 {
+				star.X = rng.NormFloat64() * 0.3
+				star.Y = rng.NormFloat64() * 0.3
+				star.PrevX = 0
+				star.PrevY = 0
+				star.D = 0.9
+				shown = false
+			}
+
+			s.frames[f][j] = starState{Star: *star, shown: shown}
 		}
 	}
 }
 
-func (s *Starfield) PaintBounds(bounds image.Rectangle, frameIdx int) image.Image {
+func (s *Starfield) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
 	return image.Rect(0, 0, 64, 32)
 }
 
@@ -122,21 +118,16 @@ func (s *Starfield) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int)
 	dc.SetColor(Black)
 	dc.Clear()
 
-	for j := 0; j < len(s.stars); j++ {
-		s.stars[j].Tick()
-		if math.Abs(s.stars[j].X) > 1.0 || math.Abs(s.stars[j].Y) > 1.0 {
-			s.stars[j].X = rand.NormFloat64() * 0.3
-			s.stars[j].Y = rand.NormFloat64() * 0.3
-			s.stars[j].PrevX = 0
-			s.stars[j].PrevY = 0
-			s.stars[j].D = 0.9 //rand.Float64()
+	s.prepare.Do(s.prepareFrames)
+	for _, star := range s.frames[ModInt(frameIdx, starfieldFrames)] {
+		if !star.shown {
 			continue
 		}
 
-		pX := int(math.Round(float64(bounds.Dx()) * (s.stars[j].PrevX + 0.5)))
-		pY := int(math.Round(float64(bounds.Dy()) * (s.stars[j].PrevY + 0.5)))
-		X := int(math.Round(float64(bounds.Dx()) * (s.stars[j].X + 0.5)))
-		Y := int(math.Round(float64(bounds.Dy()) * (s.stars[j].Y + 0.5)))
+		pX := int(math.Round(float64(bounds.Dx()) * (star.PrevX + 0.5)))
+		pY := int(math.Round(float64(bounds.Dy()) * (star.PrevY + 0.5)))
+		X := int(math.Round(float64(bounds.Dx()) * (star.X + 0.5)))
+		Y := int(math.Round(float64(bounds.Dy()) * (star.Y + 0.5)))
 
 		if pX != 0 && pY != 0 && (pX != X || pY != Y) {
 			dc.SetColor(color.RGBA{0x22, 0x22, 0x22, 0xff})
@@ -150,5 +141,5 @@ func (s *Starfield) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int)
 }
 
 func (s *Starfield) FrameCount() int {
-	return 300
+	return starfieldFrames
 }
@@ -0,0 +1,34 @@
+package render
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStarfieldSeed(t *testing.T) {
+	bounds := image.Rect(0, 0, 64, 32)
+
+	// Frames are the same however many times, and in whatever order,
+	// they're painted
+	sf := &Starfield{Seed: 4711}
+	later := PaintWidget(sf, bounds, 20)
+	assert.Equal(t, PaintWidget(sf, bounds, 3), PaintWidget(sf, bounds, 3))
+	assert.Equal(t, later, PaintWidget(sf, bounds, 20))
+	assert.NotEqual(t, later, PaintWidget(sf, bounds, 3))
+
+	// Other seeds place the stars elsewhere
+	assert.NotEqual(t, later, PaintWidget(&Starfield{Seed: 4712}, bounds, 20))
+}
+
+func TestStarfieldWithoutSeed(t *testing.T) {
+	bounds := image.Rect(0, 0, 64, 32)
+
+	// Without a seed, every starfield places its stars at random, but
+	// keeps them in place for as long as it's painted
+	sf := &Starfield{}
+	frame := PaintWidget(sf, bounds, 20)
+	assert.Equal(t, frame, PaintWidget(sf, bounds, 20))
+	assert.NotEqual(t, frame, PaintWidget(&Starfield{}, bounds, 20))
+}
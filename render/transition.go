@@ -0,0 +1,100 @@
+package render
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/tidbyt/gg"
+)
+
+// Transition paints the change from one widget to the next. It's
+// used by Sequence between its children, and between Roots that are
+// rendered one after the other.
+//
+// The animation package provides the transitions available to apps.
+type Transition interface {
+	// FrameCount returns the number of frames the transition lasts.
+	FrameCount() int
+
+	// PaintTransition paints frame frameIdx of the transition within
+	// bounds. The from and to widgets are still: from shows the last
+	// frame of the outgoing widget, and to the first frame of the
+	// incoming one, whatever frame they're painted at.
+	PaintTransition(dc *gg.Context, bounds image.Rectangle, from, to Widget, frameIdx int)
+}
+
+// PaintTransition paints the frames of t going from one painted frame
+// to the next. Both frames must have the same dimensions.
+func PaintTransition(t Transition, from, to image.Image, solidBackground bool) []image.Image {
+	bounds := image.Rect(0, 0, from.Bounds().Dx(), from.Bounds().Dy())
+	frames := make([]image.Image, t.FrameCount())
+
+	for i := range frames {
+		dc := gg.NewContext(bounds.Dx(), bounds.Dy())
+		if solidBackground {
+			dc.SetColor(color.Black)
+			dc.Clear()
+		}
+
+		dc.Push()
+		t.PaintTransition(dc, bounds, stillImage{from}, stillImage{to}, i)
+		dc.Pop()
+		frames[i] = dc.Image()
+	}
+
+	return frames
+}
+
+// PaintWithOpacity calls paint to draw onto a layer, and composites
+// the layer onto dc with the given opacity, between 0 and 1.
+func PaintWithOpacity(dc *gg.Context, opacity float64, paint func(dc *gg.Context)) {
+	if opacity >= 1 {
+		paint(dc)
+		return
+	}
+	if opacity <= 0 {
+		return
+	}
+
+	layer := newLayer(dc)
+	paint(layer)
+	drawLayer(dc, layer, BlendNormal, opacity)
+}
+
+// still paints a single frame of its child, whatever frame it's asked
+// to paint.
+type still struct {
+	Widget
+
+	child Widget
+	frame int
+}
+
+func (s still) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return s.child.PaintBounds(bounds, s.frame)
+}
+
+func (s still) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	s.child.Paint(dc, bounds, s.frame)
+}
+
+func (s still) FrameCount() int {
+	return 1
+}
+
+// stillImage paints an already painted frame.
+type stillImage struct {
+	image.Image
+}
+
+func (s stillImage) PaintBounds(bounds image.Rectangle, frameIdx int) image.Rectangle {
+	return image.Rect(0, 0, s.Bounds().Dx(), s.Bounds().Dy())
+}
+
+func (s stillImage) Paint(dc *gg.Context, bounds image.Rectangle, frameIdx int) {
+	dc.DrawImage(s.Image, 0, 0)
+}
+
+func (s stillImage) FrameCount() int {
+	return 1
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"reflect"
+)
+
+var (
+	widgetType      = reflect.TypeOf((*Widget)(nil)).Elem()
+	widgetSliceType = reflect.TypeOf([]Widget(nil))
+	widgetRowsType  = reflect.TypeOf([][]Widget(nil))
+)
+
+// mapWidgets returns a copy of the widget tree rooted at w, with fn
+// applied to every widget in it. Children are mapped before their
+// parents, so fn always sees a parent with its mapped children.
+//
+// Children are found by looking for exported fields of type Widget,
+// []Widget or [][]Widget. The tree passed in is left untouched.
+func mapWidgets(w Widget, fn func(Widget) Widget) Widget {
+	if w == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(w)
+	isPtr := v.Kind() == reflect.Ptr
+	if isPtr {
+		if v.IsNil() {
+			return fn(w)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fn(w)
+	}
+
+	cp := reflect.New(v.Type())
+	cp.Elem().Set(v)
+	s := cp.Elem()
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		if field.Anonymous || field.PkgPath != "" {
+			continue
+		}
+
+		f := s.Field(i)
+		switch field.Type {
+		case widgetType:
+			if f.IsNil() {
+				continue
+			}
+			if child := mapWidgets(f.Interface().(Widget), fn); child != nil {
+				f.Set(reflect.ValueOf(child))
+			} else {
+				f.Set(reflect.Zero(widgetType))
+			}
+
+		case widgetSliceType:
+			f.Set(reflect.ValueOf(mapWidgetSlice(f.Interface().([]Widget), fn)))
+
+		case widgetRowsType:
+			rows := f.Interface().([][]Widget)
+			if rows == nil {
+				continue
+			}
+			mapped := make([][]Widget, len(rows))
+			for j, row := range rows {
+				mapped[j] = mapWidgetSlice(row, fn)
+			}
+			f.Set(reflect.ValueOf(mapped))
+		}
+	}
+
+	if isPtr {
+		return fn(cp.Interface().(Widget))
+	}
+	return fn(s.Interface().(Widget))
+}
+
+func mapWidgetSlice(ws []Widget, fn func(Widget) Widget) []Widget {
+	if ws == nil {
+		return nil
+	}
+
+	mapped := make([]Widget, len(ws))
+	for i, w := range ws {
+		mapped[i] = mapWidgets(w, fn)
+	}
+	return mapped
+}
@@ -24,6 +24,12 @@ type WidgetStaticSize interface {
 	Size() (int, int)
 }
 
+// WidgetWithFrameDelay has frames with durations of their own, and
+// can resample them to the frame delay of the Root it's painted in.
+type WidgetWithFrameDelay interface {
+	WithFrameDelay(delay int) Widget
+}
+
 // Computes a (mod m). Useful for handling frameIdx > num available
 // frames in Widget.Paint()
 func ModInt(a, m int) int {
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/fs"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	starlibbsoup "github.com/qri-io/starlib/bsoup"
 	starlibgzip "github.com/qri-io/starlib/compress/gzip"
@@ -61,8 +63,18 @@ type Applet struct {
 
 	loader       ModuleLoader
 	initializers []ThreadInitializer
+	now          func() time.Time
+	seed         *int64
 	loadedPaths  map[string]bool
 
+	// What's needed to reuse files from a previous version of the
+	// applet: a hash of each file, the local files each of them loads,
+	// and which files didn't change.
+	prev    *Applet
+	sources map[string][sha256.Size]byte
+	deps    map[string][]string
+	reused  map[string]bool
+
 	mainFun    *starlark.Function
 	schemaFile string
 
@@ -112,6 +124,37 @@ func WithPrintDisabled() AppletOption {
 	return WithPrintFunc(func(thread *starlark.Thread, msg string) {})
 }
 
+// WithTime makes the applet run as if the current time were t. It's
+// returned by `time.now()`, used by the sunrise module when no date is
+// given, and picks the seed window of the random module.
+func WithTime(t time.Time) AppletOption {
+	return func(a *Applet) error {
+		a.now = func() time.Time {
+			return t
+		}
+		return nil
+	}
+}
+
+// WithSeed makes every run of the applet use the same random numbers,
+// whatever the time, by seeding the random module with seed.
+func WithSeed(seed int64) AppletOption {
+	return func(a *Applet) error {
+		a.seed = &seed
+		return nil
+	}
+}
+
+// WithPrevious loads the applet as a new version of prev. Starlark
+// files are only run again if they, or any file they load, changed
+// since prev was loaded. The others keep their globals from prev.
+func WithPrevious(prev *Applet) AppletOption {
+	return func(a *Applet) error {
+		a.prev = prev
+		return nil
+	}
+}
+
 func NewApplet(id string, src []byte, opts ...AppletOption) (*Applet, error) {
 	fn := id
 	if !strings.HasSuffix(fn, ".star") {
@@ -132,6 +175,9 @@ func NewAppletFromFS(id string, fsys fs.FS, opts ...AppletOption) (*Applet, erro
 		ID:          id,
 		Globals:     make(map[string]starlark.StringDict),
 		loadedPaths: make(map[string]bool),
+		sources:     make(map[string][sha256.Size]byte),
+		deps:        make(map[string][]string),
+		reused:      make(map[string]bool),
 	}
 
 	for _, opt := range opts {
@@ -140,7 +186,12 @@ func NewAppletFromFS(id string, fsys fs.FS, opts ...AppletOption) (*Applet, erro
 		}
 	}
 
-	if err := a.load(fsys); err != nil {
+	err := a.load(fsys)
+
+	// don't hold on to every previous version of the applet
+	a.prev = nil
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -303,6 +354,14 @@ func (a *Applet) Call(ctx context.Context, callable *starlark.Function, args ...
 		t.Cancel(context.Cause(ctx).Error())
 	})
 
+	if stats := runStatsFromContext(ctx); stats != nil {
+		start := time.Now()
+		defer func() {
+			stats.Steps += t.ExecutionSteps()
+			stats.ScriptTime += time.Since(start)
+		}()
+	}
+
 	resultVal, err := starlark.Call(t, callable, args, nil)
 	if err != nil {
 		evalErr, ok := err.(*starlark.EvalError)
@@ -330,6 +389,20 @@ func (a *Applet) PathsForBundle() []string {
 	return paths
 }
 
+// ReusedPaths returns the Starlark files that were kept as they were in
+// the previous version of the applet, given with `WithPrevious`, rather
+// than run again.
+func (a *Applet) ReusedPaths() []string {
+	paths := []string{}
+	for path := range a.reused {
+		if strings.HasSuffix(path, ".star") {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
 func (a *Applet) load(fsys fs.FS) (err error) {
 	// list files in the root directory of fsys
 	rootDir, err := fs.ReadDir(fsys, ".")
@@ -386,6 +459,7 @@ func (a *Applet) ensureLoaded(fsys fs.FS, pathToLoad string, currentlyLoading ..
 	if err != nil {
 		return fmt.Errorf("reading %s: %v", pathToLoad, err)
 	}
+	a.sources[pathToLoad] = sha256.Sum256(src)
 
 	predeclared := starlark.StringDict{
 		"struct": starlark.NewBuiltin("struct", starlarkstruct.Make),
@@ -401,6 +475,8 @@ func (a *Applet) ensureLoaded(fsys fs.FS, pathToLoad string, currentlyLoading ..
 
 		// if the module exists on the filesystem, load it
 		if _, err := fs.Stat(fsys, modulePath); err == nil {
+			a.deps[pathToLoad] = append(a.deps[pathToLoad], modulePath)
+
 			// ensure the module is loaded, and pass the currentlyLoading slice
 			// to detect circular dependencies
 			if err := a.ensureLoaded(fsys, modulePath, currentlyLoading...); err != nil {
@@ -420,18 +496,25 @@ func (a *Applet) ensureLoaded(fsys fs.FS, pathToLoad string, currentlyLoading ..
 
 	switch path.Ext(pathToLoad) {
 	case ".star":
-		globals, err := starlark.ExecFileOptions(
-			&syntax.FileOptions{
-				Set:       true,
-				Recursion: true,
-			},
-			thread,
-			path.Join(a.ID, pathToLoad),
-			src,
-			predeclared,
-		)
+		globals, err := a.reuse(fsys, pathToLoad, currentlyLoading)
 		if err != nil {
-			return fmt.Errorf("starlark.ExecFile: %v", err)
+			return err
+		}
+
+		if globals == nil {
+			globals, err = starlark.ExecFileOptions(
+				&syntax.FileOptions{
+					Set:       true,
+					Recursion: true,
+				},
+				thread,
+				path.Join(a.ID, pathToLoad),
+				src,
+				predeclared,
+			)
+			if err != nil {
+				return fmt.Errorf("starlark.ExecFile: %v", err)
+			}
 		}
 		a.Globals[pathToLoad] = globals
 
@@ -471,6 +554,10 @@ func (a *Applet) ensureLoaded(fsys fs.FS, pathToLoad string, currentlyLoading ..
 		}
 
 	default:
+		if a.prev != nil && a.prev.sources[pathToLoad] == a.sources[pathToLoad] {
+			a.reused[pathToLoad] = true
+		}
+
 		a.Globals[pathToLoad] = starlark.StringDict{
 			"file": &file.File{
 				FS:   fsys,
@@ -482,6 +569,35 @@ func (a *Applet) ensureLoaded(fsys fs.FS, pathToLoad string, currentlyLoading ..
 	return nil
 }
 
+// Returns the globals of a Starlark file from the previous version of
+// the applet, if neither the file nor any of the files it loads changed
+// since. Otherwise, it returns nil and the file has to be run again.
+func (a *Applet) reuse(fsys fs.FS, pathToLoad string, currentlyLoading []string) (starlark.StringDict, error) {
+	if a.prev == nil || a.prev.sources[pathToLoad] != a.sources[pathToLoad] {
+		return nil, nil
+	}
+
+	globals, ok := a.prev.Globals[pathToLoad]
+	if !ok {
+		return nil, nil
+	}
+
+	// the file is unchanged, so it loads the same files as before
+	for _, dep := range a.prev.deps[pathToLoad] {
+		if err := a.ensureLoaded(fsys, dep, currentlyLoading...); err != nil {
+			return nil, err
+		}
+		if !a.reused[dep] {
+			return nil, nil
+		}
+	}
+
+	a.deps[pathToLoad] = a.prev.deps[pathToLoad]
+	a.reused[pathToLoad] = true
+
+	return globals, nil
+}
+
 func (a *Applet) newThread(ctx context.Context) *starlark.Thread {
 	t := &starlark.Thread{
 		Name: a.ID,
@@ -492,7 +608,14 @@ func (a *Applet) newThread(ctx context.Context) *starlark.Thread {
 	}
 
 	starlarkutil.AttachThreadContext(ctx, t)
-	random.AttachToThread(t)
+	if a.now != nil {
+		starlarkutil.AttachThreadClock(a.now, t)
+	}
+	if a.seed != nil {
+		random.AttachSeededToThread(t, *a.seed)
+	} else {
+		random.AttachToThread(t)
+	}
 
 	for _, init := range a.initializers {
 		t = init(t)
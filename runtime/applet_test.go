@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	starlibbase64 "github.com/qri-io/starlib/encoding/base64"
 	"github.com/stretchr/testify/assert"
@@ -331,6 +332,67 @@ def main():
 	assert.ErrorContains(t, err, "not exported")
 }
 
+func TestWithPrevious(t *testing.T) {
+	src := `
+load("render.star", "render")
+load("hello.star", "hello")
+
+print("ran src.star")
+
+def main():
+    return render.Root(child=render.Text(hello))
+`
+
+	helloSrc := `
+print("ran hello.star")
+
+hello = "hello world"
+`
+
+	var ran []string
+	print := WithPrintFunc(func(thread *starlark.Thread, msg string) {
+		ran = append(ran, msg)
+	})
+
+	vfs := fstest.MapFS{
+		"src.star":   {Data: []byte(src)},
+		"hello.star": {Data: []byte(helloSrc)},
+	}
+
+	prev, err := NewAppletFromFS("test", vfs, print)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ran hello.star", "ran src.star"}, ran)
+	assert.Equal(t, []string{}, prev.ReusedPaths())
+
+	// Nothing changed, so nothing is run again
+	ran = nil
+	app, err := NewAppletFromFS("test", vfs, print, WithPrevious(prev))
+	require.NoError(t, err)
+	assert.Nil(t, ran)
+	assert.Equal(t, []string{"hello.star", "src.star"}, app.ReusedPaths())
+	assert.Equal(t, "src.star", app.MainFile)
+
+	roots, err := app.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(roots))
+
+	// Changing a file runs it again, as well as the files loading it
+	vfs["hello.star"] = &fstest.MapFile{Data: []byte(`hello = "hello again"`)}
+	ran = nil
+	app, err = NewAppletFromFS("test", vfs, print, WithPrevious(app))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ran src.star"}, ran)
+	assert.Equal(t, []string{}, app.ReusedPaths())
+
+	// Changing the file with main() only runs that file again
+	vfs["src.star"] = &fstest.MapFile{Data: []byte(src + "\n# changed\n")}
+	ran = nil
+	app, err = NewAppletFromFS("test", vfs, print, WithPrevious(app))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ran src.star"}, ran)
+	assert.Equal(t, []string{"hello.star"}, app.ReusedPaths())
+}
+
 func TestCircularDependency(t *testing.T) {
 	// Module A depends on module B
 	srcA := `
@@ -454,3 +516,29 @@ def main():
 }
 
 // TODO: test Screens, especially Screens.Render()
+
+func TestWithTime(t *testing.T) {
+	src := `
+load("render.star", "render")
+load("time.star", "time")
+def main():
+	now = time.now().in_location("Asia/Tokyo")
+	if now.format("2006-01-02 15:04") != "2025-12-31 23:59":
+		fail("unexpected time", now)
+	return render.Root(child=render.Box())
+`
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	app, err := NewApplet("test.star", []byte(src), WithTime(time.Date(2025, 12, 31, 23, 59, 0, 0, tokyo)))
+	require.NoError(t, err)
+	_, err = app.Run(context.Background())
+	assert.NoError(t, err)
+
+	// Without the option, the actual time is used
+	app, err = NewApplet("test.star", []byte(src))
+	require.NoError(t, err)
+	_, err = app.Run(context.Background())
+	assert.ErrorContains(t, err, "unexpected time")
+}
@@ -68,17 +68,27 @@ var Packages = []Package{
 		GoWidgetName:   "Widget",
 		Types: []reflect.Value{
 			reflect.ValueOf(new(render.Animation)),
+			reflect.ValueOf(new(render.Arc)),
+			reflect.ValueOf(new(render.BarChart)),
 			reflect.ValueOf(new(render.Box)),
 			reflect.ValueOf(new(render.Circle)),
 			reflect.ValueOf(new(render.Column)),
+			reflect.ValueOf(new(render.Gauge)),
+			reflect.ValueOf(new(render.Grid)),
+			reflect.ValueOf(new(render.Hold)),
 			reflect.ValueOf(new(render.Image)),
+			reflect.ValueOf(new(render.Line)),
 			reflect.ValueOf(new(render.Marquee)),
 			reflect.ValueOf(new(render.Padding)),
 			reflect.ValueOf(new(render.PieChart)),
 			reflect.ValueOf(new(render.Plot)),
+			reflect.ValueOf(new(render.Polygon)),
+			reflect.ValueOf(new(render.ProgressBar)),
 			reflect.ValueOf(new(render.Root)),
+			reflect.ValueOf(new(render.RoundedBox)),
 			reflect.ValueOf(new(render.Row)),
 			reflect.ValueOf(new(render.Sequence)),
+			reflect.ValueOf(new(render.Sparkline)),
 			reflect.ValueOf(new(render.Stack)),
 			reflect.ValueOf(new(render.Text)),
 			reflect.ValueOf(new(render.WrappedText)),
@@ -97,10 +107,13 @@ var Packages = []Package{
 		GoWidgetName:   "render_runtime.Widget",
 		Types: []reflect.Value{
 			reflect.ValueOf(new(animation.Keyframe)),
+			reflect.ValueOf(new(animation.Opacity)),
 			reflect.ValueOf(new(animation.Origin)),
 			reflect.ValueOf(new(animation.Rotate)),
 			reflect.ValueOf(new(animation.Scale)),
+			reflect.ValueOf(new(animation.Tint)),
 			reflect.ValueOf(new(animation.Transformation)),
+			reflect.ValueOf(new(animation.Transition)),
 			reflect.ValueOf(new(animation.Translate)),
 
 			// Legacy
@@ -140,11 +153,21 @@ var TypeMap = map[reflect.Type]Type{
 		DocType:      "float / int",
 		TemplatePath: "./runtime/gen/attr/float.tmpl",
 	},
+	reflect.TypeOf(new(float64)): {
+		GoType:       "starlark.Value",
+		DocType:      "float / int",
+		TemplatePath: "./runtime/gen/attr/optional_float.tmpl",
+	},
 	toDecayedType(new(bool)): {
 		GoType:       "starlark.Bool",
 		DocType:      "bool",
 		TemplatePath: "./runtime/gen/attr/bool.tmpl",
 	},
+	toDecayedType(new([]string)): {
+		GoType:       "*starlark.List",
+		DocType:      "[str]",
+		TemplatePath: "./runtime/gen/attr/strings.tmpl",
+	},
 
 	// Render types
 	toDecayedType(new(render.Insets)): {
@@ -162,6 +185,16 @@ var TypeMap = map[reflect.Type]Type{
 		DocType:      "[Widget]",
 		TemplatePath: "./runtime/gen/attr/children.tmpl",
 	},
+	toDecayedType(new([][]render.Widget)): {
+		GoType:       "*starlark.List",
+		DocType:      "[[Widget]]",
+		TemplatePath: "./runtime/gen/attr/rows.tmpl",
+	},
+	toDecayedType(new(render.Transition)): {
+		GoType:       "starlark.Value",
+		DocType:      "Transition",
+		TemplatePath: "./runtime/gen/attr/transition.tmpl",
+	},
 	toDecayedType(new(color.Color)): {
 		GoType:        "starlark.String",
 		DocType:       `color`,
@@ -183,6 +216,13 @@ var TypeMap = map[reflect.Type]Type{
 		GenerateField: true,
 	},
 
+	// Render `BarChart` types
+	toDecayedType(new([][]float64)): {
+		GoType:       "*starlark.List",
+		DocType:      "[float / [float]]",
+		TemplatePath: "./runtime/gen/attr/bars.tmpl",
+	},
+
 	// Render `Plot` types`
 	toDecayedType(new([2]float64)): {
 		GoType:       "starlark.Tuple",
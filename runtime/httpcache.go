@@ -75,11 +75,14 @@ func (c *cacheClient) RoundTrip(req *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("failed to generate cache key: %w", err)
 	}
 
+	stats := runStatsFromContext(ctx)
+
 	if req.Method == "GET" || req.Method == "HEAD" || req.Method == "POST" {
 		b, exists, err := c.cache.Get(nil, key)
 		if exists && err == nil {
 			if res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req); err == nil {
 				res.Header.Set("tidbyt-cache-status", "HIT")
+				stats.addHTTPRequest("HIT")
 				return res, nil
 			}
 		}
@@ -101,6 +104,9 @@ func (c *cacheClient) RoundTrip(req *http.Request) (*http.Response, error) {
 		ttl := DetermineTTL(req, resp)
 		c.cache.Set(nil, key, ser, int64(ttl.Seconds()))
 		resp.Header.Set("tidbyt-cache-status", "MISS")
+		stats.addHTTPRequest("MISS")
+	} else {
+		stats.addHTTPRequest("")
 	}
 
 	return resp, err
@@ -33,14 +33,20 @@ func LoadAnimationModule() (starlark.StringDict, error) {
 
 					"Keyframe": starlark.NewBuiltin("Keyframe", newKeyframe),
 
+					"Opacity": starlark.NewBuiltin("Opacity", newOpacity),
+
 					"Origin": starlark.NewBuiltin("Origin", newOrigin),
 
 					"Rotate": starlark.NewBuiltin("Rotate", newRotate),
 
 					"Scale": starlark.NewBuiltin("Scale", newScale),
 
+					"Tint": starlark.NewBuiltin("Tint", newTint),
+
 					"Transformation": starlark.NewBuiltin("Transformation", newTransformation),
 
+					"Transition": starlark.NewBuiltin("Transition", newTransition),
+
 					"Translate": starlark.NewBuiltin("Translate", newTranslate),
 				},
 			},
@@ -269,6 +275,10 @@ func newKeyframe(
 			w.Transforms = append(w.Transforms, transformsVal.Scale)
 		case *Rotate:
 			w.Transforms = append(w.Transforms, transformsVal.Rotate)
+		case *Opacity:
+			w.Transforms = append(w.Transforms, transformsVal.Opacity)
+		case *Tint:
+			w.Transforms = append(w.Transforms, transformsVal.Tint)
 		default:
 			return nil, fmt.Errorf("expected transform, but got '%s'", transformsVal.Type())
 		}
@@ -322,6 +332,71 @@ func (w *Keyframe) Hash() (uint32, error) {
 	return uint32(sum), err
 }
 
+type Opacity struct {
+	animation.Opacity
+
+	starlarkValue starlark.Value
+}
+
+func newOpacity(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		value starlark.Value
+	)
+
+	if err := starlark.UnpackArgs(
+		"Opacity",
+		args, kwargs,
+		"value", &value,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Opacity: %s", err)
+	}
+
+	w := &Opacity{}
+
+	w.starlarkValue = value
+	if val, ok := starlark.AsFloat(w.starlarkValue); ok {
+		w.Value = val
+	} else {
+		return nil, fmt.Errorf("expected number, but got: %s", w.starlarkValue.String())
+	}
+
+	return w, nil
+}
+
+func (w *Opacity) AttrNames() []string {
+	return []string{
+		"value",
+	}
+}
+
+func (w *Opacity) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "value":
+
+		return w.starlarkValue, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Opacity) String() string       { return "Opacity(...)" }
+func (w *Opacity) Type() string         { return "Opacity" }
+func (w *Opacity) Freeze()              {}
+func (w *Opacity) Truth() starlark.Bool { return true }
+
+func (w *Opacity) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
 type Origin struct {
 	animation.Origin
 
@@ -547,6 +622,73 @@ func (w *Scale) Hash() (uint32, error) {
 	return uint32(sum), err
 }
 
+type Tint struct {
+	animation.Tint
+
+	starlarkColor starlark.String
+}
+
+func newTint(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		color starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"Tint",
+		args, kwargs,
+		"color", &color,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Tint: %s", err)
+	}
+
+	w := &Tint{}
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	return w, nil
+}
+
+func (w *Tint) AttrNames() []string {
+	return []string{
+		"color",
+	}
+}
+
+func (w *Tint) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Tint) String() string       { return "Tint(...)" }
+func (w *Tint) Type() string         { return "Tint" }
+func (w *Tint) Freeze()              {}
+func (w *Tint) Truth() starlark.Bool { return true }
+
+func (w *Tint) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
 type Transformation struct {
 	render_runtime.Widget
 
@@ -788,6 +930,101 @@ func transformationFrameCount(
 	return starlark.MakeInt(count), nil
 }
 
+type Transition struct {
+	animation.Transition
+
+	starlarkCurve starlark.Value
+}
+
+func newTransition(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		effect    starlark.String
+		duration  starlark.Int
+		direction starlark.String
+		curve     starlark.Value
+	)
+
+	if err := starlark.UnpackArgs(
+		"Transition",
+		args, kwargs,
+		"effect", &effect,
+		"duration?", &duration,
+		"direction?", &direction,
+		"curve?", &curve,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Transition: %s", err)
+	}
+
+	w := &Transition{}
+
+	w.Effect = effect.GoString()
+
+	w.Duration = int(duration.BigInt().Int64())
+
+	w.Direction = direction.GoString()
+
+	w.starlarkCurve = curve
+	if curve == nil {
+		w.Curve = animation.DefaultCurve
+	} else if val, err := CurveFromStarlark(curve); err == nil {
+		w.Curve = val
+	} else {
+		return nil, err
+	}
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Transition) AttrNames() []string {
+	return []string{
+		"effect", "duration", "direction", "curve",
+	}
+}
+
+func (w *Transition) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "effect":
+
+		return starlark.String(w.Effect), nil
+
+	case "duration":
+
+		return starlark.MakeInt(int(w.Duration)), nil
+
+	case "direction":
+
+		return starlark.String(w.Direction), nil
+
+	case "curve":
+
+		return w.starlarkCurve, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Transition) String() string       { return "Transition(...)" }
+func (w *Transition) Type() string         { return "Transition" }
+func (w *Transition) Freeze()              {}
+func (w *Transition) Truth() starlark.Bool { return true }
+
+func (w *Transition) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
 type Translate struct {
 	animation.Translate
 
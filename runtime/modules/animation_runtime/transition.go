@@ -0,0 +1,9 @@
+package animation_runtime
+
+import (
+	"tidbyt.dev/pixlet/render"
+)
+
+func (w *Transition) AsRenderTransition() render.Transition {
+	return &w.Transition
+}
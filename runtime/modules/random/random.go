@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"math/rand"
 	"sync"
-	"time"
 
 	"go.starlark.net/starlark"
 	"go.starlark.net/starlarkstruct"
+
+	"tidbyt.dev/pixlet/starlarkutil"
 )
 
 const (
@@ -21,19 +22,21 @@ var (
 	module starlark.StringDict
 )
 
+// AttachToThread gives the thread an RNG, seeded from the thread's
+// current time. Attach a clock to the thread first, if it has one.
 func AttachToThread(t *starlark.Thread) {
-	nowSeconds := time.Now().UnixMilli() / 1000
-
-	t.SetLocal(
-		threadRandKey,
-		rand.New(
-			// Seed RNG with a constant for brief time
-			// windows. This allows app to be "random",
-			// while still enabling Tidbyt's backend to
-			// cache the results.
-			rand.NewSource(nowSeconds/randomSeedWindow),
-		),
-	)
+	nowSeconds := starlarkutil.ThreadNow(t).UnixMilli() / 1000
+
+	// Seed RNG with a constant for brief time windows. This allows
+	// app to be "random", while still enabling Tidbyt's backend to
+	// cache the results.
+	AttachSeededToThread(t, nowSeconds/randomSeedWindow)
+}
+
+// AttachSeededToThread gives the thread an RNG seeded with seed, so
+// that it always returns the same numbers.
+func AttachSeededToThread(t *starlark.Thread, seed int64) {
+	t.SetLocal(threadRandKey, rand.New(rand.NewSource(seed)))
 }
 
 func LoadModule() (starlark.StringDict, error) {
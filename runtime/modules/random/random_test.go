@@ -3,9 +3,11 @@ package random_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.starlark.net/starlark"
 	"tidbyt.dev/pixlet/runtime"
 )
 
@@ -55,3 +57,59 @@ func TestRandom(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, screens)
 }
+
+func TestRandomSeedWindow(t *testing.T) {
+	src := `
+load("random.star", "random")
+
+def main():
+	print(random.number(0, 1 << 30))
+	return []
+`
+
+	// Renders at the same simulated time share the seed window, and
+	// get the same numbers.
+	numberAt := func(now time.Time) string {
+		var printed string
+		app, err := runtime.NewApplet("random_test.star", []byte(src), runtime.WithTime(now), runtime.WithPrintFunc(func(thread *starlark.Thread, msg string) {
+			printed = msg
+		}))
+		require.NoError(t, err)
+
+		_, err = app.Run(context.Background())
+		require.NoError(t, err)
+		return printed
+	}
+
+	now := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, numberAt(now), numberAt(now.Add(time.Second)))
+	assert.NotEqual(t, numberAt(now), numberAt(now.Add(time.Hour)))
+}
+
+func TestRandomWithSeed(t *testing.T) {
+	src := `
+load("random.star", "random")
+
+def main():
+	print(random.number(0, 1 << 30))
+	return []
+`
+
+	// With a seed, the numbers don't depend on the time
+	numberAt := func(now time.Time, opts ...runtime.AppletOption) string {
+		var printed string
+		opts = append(opts, runtime.WithTime(now), runtime.WithPrintFunc(func(thread *starlark.Thread, msg string) {
+			printed = msg
+		}))
+		app, err := runtime.NewApplet("random_test.star", []byte(src), opts...)
+		require.NoError(t, err)
+
+		_, err = app.Run(context.Background())
+		require.NoError(t, err)
+		return printed
+	}
+
+	now := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+	assert.Equal(t, numberAt(now, runtime.WithSeed(4711)), numberAt(now.Add(time.Hour), runtime.WithSeed(4711)))
+	assert.NotEqual(t, numberAt(now, runtime.WithSeed(4711)), numberAt(now, runtime.WithSeed(4712)))
+}
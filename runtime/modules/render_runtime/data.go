@@ -71,6 +71,33 @@ func WeightsFromStarlark(list *starlark.List) ([]float64, error) {
 	return result, nil
 }
 
+func BarSeriesFromStarlark(list *starlark.List) ([][]float64, error) {
+	result := make([][]float64, 0)
+
+	for i := 0; i < list.Len(); i++ {
+		switch v := list.Index(i).(type) {
+		case starlark.Indexable:
+			stack := make([]float64, 0, v.Len())
+			for j := 0; j < v.Len(); j++ {
+				f, ok := starlark.AsFloat(v.Index(j))
+				if !ok {
+					return nil, fmt.Errorf("invalid type for bar %d element %d: %s (expected float)", i, j, v.Index(j).Type())
+				}
+				stack = append(stack, f)
+			}
+			result = append(result, stack)
+		default:
+			f, ok := starlark.AsFloat(v)
+			if !ok {
+				return nil, fmt.Errorf("invalid type for bar %d: %s (expected float or list of float)", i, v.Type())
+			}
+			result = append(result, []float64{f})
+		}
+	}
+
+	return result, nil
+}
+
 func ColorSeriesFromStarlark(list *starlark.List) ([]color.Color, error) {
 	result := make([]color.Color, 0)
 
@@ -91,3 +118,47 @@ func ColorSeriesFromStarlark(list *starlark.List) ([]color.Color, error) {
 
 	return result, nil
 }
+
+func StringsFromStarlark(list *starlark.List) ([]string, error) {
+	result := make([]string, 0)
+
+	for i := 0; i < list.Len(); i++ {
+		switch v := list.Index(i).(type) {
+		case starlark.String:
+			result = append(result, v.GoString())
+		case starlark.Int:
+			result = append(result, v.String())
+		default:
+			return nil, fmt.Errorf("invalid type at index %d: %s (expected str or int)", i, v.Type())
+		}
+	}
+
+	return result, nil
+}
+
+func WidgetRowsFromStarlark(list *starlark.List) ([][]render.Widget, error) {
+	result := make([][]render.Widget, 0, list.Len())
+
+	for i := 0; i < list.Len(); i++ {
+		row, ok := list.Index(i).(starlark.Indexable)
+		if !ok {
+			return nil, fmt.Errorf("invalid type for row %d: %s (expected list of Widget)", i, list.Index(i).Type())
+		}
+
+		cells := make([]render.Widget, row.Len())
+		for j := 0; j < row.Len(); j++ {
+			switch cell := row.Index(j).(type) {
+			case starlark.NoneType:
+				// empty cell
+			case Widget:
+				cells[j] = cell.AsRenderWidget()
+			default:
+				return nil, fmt.Errorf("invalid type for cell (%d, %d): %s (expected Widget or None)", i, j, cell.Type())
+			}
+		}
+
+		result = append(result, cells)
+	}
+
+	return result, nil
+}
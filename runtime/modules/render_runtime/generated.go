@@ -37,14 +37,26 @@ func LoadRenderModule() (starlark.StringDict, error) {
 
 					"Animation": starlark.NewBuiltin("Animation", newAnimation),
 
+					"Arc": starlark.NewBuiltin("Arc", newArc),
+
+					"BarChart": starlark.NewBuiltin("BarChart", newBarChart),
+
 					"Box": starlark.NewBuiltin("Box", newBox),
 
 					"Circle": starlark.NewBuiltin("Circle", newCircle),
 
 					"Column": starlark.NewBuiltin("Column", newColumn),
 
+					"Gauge": starlark.NewBuiltin("Gauge", newGauge),
+
+					"Grid": starlark.NewBuiltin("Grid", newGrid),
+
+					"Hold": starlark.NewBuiltin("Hold", newHold),
+
 					"Image": starlark.NewBuiltin("Image", newImage),
 
+					"Line": starlark.NewBuiltin("Line", newLine),
+
 					"Marquee": starlark.NewBuiltin("Marquee", newMarquee),
 
 					"Padding": starlark.NewBuiltin("Padding", newPadding),
@@ -53,12 +65,20 @@ func LoadRenderModule() (starlark.StringDict, error) {
 
 					"Plot": starlark.NewBuiltin("Plot", newPlot),
 
+					"Polygon": starlark.NewBuiltin("Polygon", newPolygon),
+
+					"ProgressBar": starlark.NewBuiltin("ProgressBar", newProgressBar),
+
 					"Root": starlark.NewBuiltin("Root", newRoot),
 
+					"RoundedBox": starlark.NewBuiltin("RoundedBox", newRoundedBox),
+
 					"Row": starlark.NewBuiltin("Row", newRow),
 
 					"Sequence": starlark.NewBuiltin("Sequence", newSequence),
 
+					"Sparkline": starlark.NewBuiltin("Sparkline", newSparkline),
+
 					"Stack": starlark.NewBuiltin("Stack", newStack),
 
 					"Text": starlark.NewBuiltin("Text", newText),
@@ -79,6 +99,10 @@ type Rootable interface {
 type Widget interface {
 	AsRenderWidget() render.Widget
 }
+
+type Transitionable interface {
+	AsRenderTransition() render.Transition
+}
 type Animation struct {
 	Widget
 
@@ -183,6 +207,336 @@ func animationFrameCount(
 	return starlark.MakeInt(count), nil
 }
 
+type Arc struct {
+	Widget
+
+	render.Arc
+
+	starlarkStart starlark.Value
+
+	starlarkEnd starlark.Value
+
+	starlarkColor starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newArc(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		diameter     starlark.Int
+		start        starlark.Value
+		end          starlark.Value
+		color        starlark.String
+		stroke_width starlark.Int
+		rendering    starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"Arc",
+		args, kwargs,
+		"diameter", &diameter,
+		"start", &start,
+		"end", &end,
+		"color?", &color,
+		"stroke_width?", &stroke_width,
+		"rendering?", &rendering,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Arc: %s", err)
+	}
+
+	w := &Arc{}
+
+	w.Diameter = int(diameter.BigInt().Int64())
+
+	w.starlarkStart = start
+	if val, ok := starlark.AsFloat(w.starlarkStart); ok {
+		w.Start = val
+	} else {
+		return nil, fmt.Errorf("expected number, but got: %s", w.starlarkStart.String())
+	}
+
+	w.starlarkEnd = end
+	if val, ok := starlark.AsFloat(w.starlarkEnd); ok {
+		w.End = val
+	} else {
+		return nil, fmt.Errorf("expected number, but got: %s", w.starlarkEnd.String())
+	}
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.StrokeWidth = int(stroke_width.BigInt().Int64())
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", arcFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Arc) AsRenderWidget() render.Widget {
+	return &w.Arc
+}
+
+func (w *Arc) AttrNames() []string {
+	return []string{
+		"diameter", "start", "end", "color", "stroke_width", "rendering",
+	}
+}
+
+func (w *Arc) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "diameter":
+
+		return starlark.MakeInt(int(w.Diameter)), nil
+
+	case "start":
+
+		return w.starlarkStart, nil
+
+	case "end":
+
+		return w.starlarkEnd, nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "stroke_width":
+
+		return starlark.MakeInt(int(w.StrokeWidth)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Arc) String() string       { return "Arc(...)" }
+func (w *Arc) Type() string         { return "Arc" }
+func (w *Arc) Freeze()              {}
+func (w *Arc) Truth() starlark.Bool { return true }
+
+func (w *Arc) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func arcFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Arc)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type BarChart struct {
+	Widget
+
+	render.BarChart
+
+	starlarkData *starlark.List
+
+	starlarkColors *starlark.List
+
+	starlarkColorNegative starlark.String
+
+	starlarkYLim starlark.Tuple
+
+	frame_count *starlark.Builtin
+}
+
+func newBarChart(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		data           *starlark.List
+		width          starlark.Int
+		height         starlark.Int
+		colors         *starlark.List
+		color_negative starlark.String
+		bar_width      starlark.Int
+		bar_gap        starlark.Int
+		y_lim          starlark.Tuple
+		grow_frames    starlark.Int
+	)
+
+	if err := starlark.UnpackArgs(
+		"BarChart",
+		args, kwargs,
+		"data", &data,
+		"width", &width,
+		"height", &height,
+		"colors?", &colors,
+		"color_negative?", &color_negative,
+		"bar_width?", &bar_width,
+		"bar_gap?", &bar_gap,
+		"y_lim?", &y_lim,
+		"grow_frames?", &grow_frames,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for BarChart: %s", err)
+	}
+
+	w := &BarChart{}
+
+	w.starlarkData = data
+	if val, err := BarSeriesFromStarlark(data); err == nil {
+		w.Data = val
+	} else {
+		return nil, err
+	}
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	if colors == nil {
+		colors = starlark.NewList(nil)
+	}
+	w.starlarkColors = colors
+	if val, err := ColorSeriesFromStarlark(colors); err == nil {
+		w.Colors = val
+	} else {
+		return nil, err
+	}
+
+	w.starlarkColorNegative = color_negative
+	if color_negative.Len() > 0 {
+		c, err := render.ParseColor(color_negative.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color_negative is not a valid hex string: %s", color_negative.String())
+		}
+		w.ColorNegative = c
+	}
+
+	w.BarWidth = int(bar_width.BigInt().Int64())
+
+	w.BarGap = int(bar_gap.BigInt().Int64())
+
+	w.starlarkYLim = y_lim
+	if val, err := DataPointFromStarlark(y_lim); err == nil {
+		w.YLim = val
+	} else {
+		return nil, err
+	}
+
+	w.GrowFrames = int(grow_frames.BigInt().Int64())
+
+	w.frame_count = starlark.NewBuiltin("frame_count", barchartFrameCount)
+
+	return w, nil
+}
+
+func (w *BarChart) AsRenderWidget() render.Widget {
+	return &w.BarChart
+}
+
+func (w *BarChart) AttrNames() []string {
+	return []string{
+		"data", "width", "height", "colors", "color_negative", "bar_width", "bar_gap", "y_lim", "grow_frames",
+	}
+}
+
+func (w *BarChart) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "data":
+
+		return w.starlarkData, nil
+
+	case "width":
+
+		return starlark.MakeInt(int(w.Width)), nil
+
+	case "height":
+
+		return starlark.MakeInt(int(w.Height)), nil
+
+	case "colors":
+
+		return w.starlarkColors, nil
+
+	case "color_negative":
+
+		return w.starlarkColorNegative, nil
+
+	case "bar_width":
+
+		return starlark.MakeInt(int(w.BarWidth)), nil
+
+	case "bar_gap":
+
+		return starlark.MakeInt(int(w.BarGap)), nil
+
+	case "y_lim":
+
+		return w.starlarkYLim, nil
+
+	case "grow_frames":
+
+		return starlark.MakeInt(int(w.GrowFrames)), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *BarChart) String() string       { return "BarChart(...)" }
+func (w *BarChart) Type() string         { return "BarChart" }
+func (w *BarChart) Freeze()              {}
+func (w *BarChart) Truth() starlark.Bool { return true }
+
+func (w *BarChart) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func barchartFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*BarChart)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
 type Box struct {
 	Widget
 
@@ -339,9 +693,10 @@ func newCircle(
 ) (starlark.Value, error) {
 
 	var (
-		color    starlark.String
-		diameter starlark.Int
-		child    starlark.Value
+		color     starlark.String
+		diameter  starlark.Int
+		child     starlark.Value
+		rendering starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
@@ -350,6 +705,7 @@ func newCircle(
 		"color", &color,
 		"diameter", &diameter,
 		"child?", &child,
+		"rendering?", &rendering,
 	); err != nil {
 		return nil, fmt.Errorf("unpacking arguments for Circle: %s", err)
 	}
@@ -379,8 +735,14 @@ func newCircle(
 		w.starlarkChild = child
 	}
 
+	w.Rendering = rendering.GoString()
+
 	w.frame_count = starlark.NewBuiltin("frame_count", circleFrameCount)
 
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
 	return w, nil
 }
 
@@ -390,7 +752,7 @@ func (w *Circle) AsRenderWidget() render.Widget {
 
 func (w *Circle) AttrNames() []string {
 	return []string{
-		"color", "diameter", "child",
+		"color", "diameter", "child", "rendering",
 	}
 }
 
@@ -409,6 +771,10 @@ func (w *Circle) Attr(name string) (starlark.Value, error) {
 
 		return w.starlarkChild, nil
 
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
 
@@ -567,50 +933,155 @@ func columnFrameCount(
 	return starlark.MakeInt(count), nil
 }
 
-type Image struct {
+type Gauge struct {
 	Widget
 
-	render.Image
+	render.Gauge
 
-	size *starlark.Builtin
+	starlarkValue starlark.Value
 
-	frame_count *starlark.Builtin
-}
+	starlarkMin starlark.Value
 
-func newImage(
-	thread *starlark.Thread,
+	starlarkMax starlark.Value
+
+	starlarkSweep starlark.Value
+
+	starlarkColor starlark.String
+
+	starlarkBackgroundColor starlark.String
+
+	starlarkChild starlark.Value
+
+	frame_count *starlark.Builtin
+}
+
+func newGauge(
+	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
 	kwargs []starlark.Tuple,
 ) (starlark.Value, error) {
 
 	var (
-		src    starlark.String
-		width  starlark.Int
-		height starlark.Int
+		value            starlark.Value
+		diameter         starlark.Int
+		min              starlark.Value
+		max              starlark.Value
+		thickness        starlark.Int
+		sweep            starlark.Value
+		style            starlark.String
+		color            starlark.String
+		background_color starlark.String
+		child            starlark.Value
+		grow_frames      starlark.Int
+		rendering        starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
-		"Image",
+		"Gauge",
 		args, kwargs,
-		"src", &src,
-		"width?", &width,
-		"height?", &height,
+		"value", &value,
+		"diameter", &diameter,
+		"min?", &min,
+		"max?", &max,
+		"thickness?", &thickness,
+		"sweep?", &sweep,
+		"style?", &style,
+		"color?", &color,
+		"background_color?", &background_color,
+		"child?", &child,
+		"grow_frames?", &grow_frames,
+		"rendering?", &rendering,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for Image: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for Gauge: %s", err)
 	}
 
-	w := &Image{}
+	w := &Gauge{}
 
-	w.Src = src.GoString()
+	w.starlarkValue = value
+	if val, ok := starlark.AsFloat(w.starlarkValue); ok {
+		w.Value = val
+	} else {
+		return nil, fmt.Errorf("expected number, but got: %s", w.starlarkValue.String())
+	}
 
-	w.Width = int(width.BigInt().Int64())
+	w.Diameter = int(diameter.BigInt().Int64())
 
-	w.Height = int(height.BigInt().Int64())
+	if min == nil {
+		min = starlark.None
+	}
+	w.starlarkMin = min
+	if _, isNone := min.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkMin); ok {
+			w.Min = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkMin.String())
+		}
+	}
 
-	w.size = starlark.NewBuiltin("size", imageSize)
+	if max == nil {
+		max = starlark.None
+	}
+	w.starlarkMax = max
+	if _, isNone := max.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkMax); ok {
+			w.Max = &val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkMax.String())
+		}
+	}
 
-	w.frame_count = starlark.NewBuiltin("frame_count", imageFrameCount)
+	w.Thickness = int(thickness.BigInt().Int64())
+
+	if sweep == nil {
+		sweep = starlark.None
+	}
+	w.starlarkSweep = sweep
+	if _, isNone := sweep.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkSweep); ok {
+			w.Sweep = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkSweep.String())
+		}
+	}
+
+	w.Style = style.GoString()
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.starlarkBackgroundColor = background_color
+	if background_color.Len() > 0 {
+		c, err := render.ParseColor(background_color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("background_color is not a valid hex string: %s", background_color.String())
+		}
+		w.BackgroundColor = c
+	}
+
+	if child != nil {
+		childWidget, ok := child.(Widget)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for child: %s (expected Widget)",
+				child.Type(),
+			)
+		}
+		w.Child = childWidget.AsRenderWidget()
+		w.starlarkChild = child
+	}
+
+	w.GrowFrames = int(grow_frames.BigInt().Int64())
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", gaugeFrameCount)
 
 	if err := w.Init(); err != nil {
 		return nil, err
@@ -619,37 +1090,66 @@ func newImage(
 	return w, nil
 }
 
-func (w *Image) AsRenderWidget() render.Widget {
-	return &w.Image
+func (w *Gauge) AsRenderWidget() render.Widget {
+	return &w.Gauge
 }
 
-func (w *Image) AttrNames() []string {
+func (w *Gauge) AttrNames() []string {
 	return []string{
-		"src", "width", "height", "delay",
+		"value", "diameter", "min", "max", "thickness", "sweep", "style", "color", "background_color", "child", "grow_frames", "rendering",
 	}
 }
 
-func (w *Image) Attr(name string) (starlark.Value, error) {
+func (w *Gauge) Attr(name string) (starlark.Value, error) {
 	switch name {
 
-	case "src":
+	case "value":
 
-		return starlark.String(w.Src), nil
+		return w.starlarkValue, nil
 
-	case "width":
+	case "diameter":
 
-		return starlark.MakeInt(int(w.Width)), nil
+		return starlark.MakeInt(int(w.Diameter)), nil
 
-	case "height":
+	case "min":
 
-		return starlark.MakeInt(int(w.Height)), nil
+		return w.starlarkMin, nil
 
-	case "delay":
+	case "max":
 
-		return starlark.MakeInt(int(w.Delay)), nil
+		return w.starlarkMax, nil
 
-	case "size":
-		return w.size.BindReceiver(w), nil
+	case "thickness":
+
+		return starlark.MakeInt(int(w.Thickness)), nil
+
+	case "sweep":
+
+		return w.starlarkSweep, nil
+
+	case "style":
+
+		return starlark.String(w.Style), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "background_color":
+
+		return w.starlarkBackgroundColor, nil
+
+	case "child":
+
+		return w.starlarkChild, nil
+
+	case "grow_frames":
+
+		return starlark.MakeInt(int(w.GrowFrames)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
 
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
@@ -659,54 +1159,47 @@ func (w *Image) Attr(name string) (starlark.Value, error) {
 	}
 }
 
-func (w *Image) String() string       { return "Image(...)" }
-func (w *Image) Type() string         { return "Image" }
-func (w *Image) Freeze()              {}
-func (w *Image) Truth() starlark.Bool { return true }
+func (w *Gauge) String() string       { return "Gauge(...)" }
+func (w *Gauge) Type() string         { return "Gauge" }
+func (w *Gauge) Freeze()              {}
+func (w *Gauge) Truth() starlark.Bool { return true }
 
-func (w *Image) Hash() (uint32, error) {
+func (w *Gauge) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
-func imageSize(
-	thread *starlark.Thread,
-	b *starlark.Builtin,
-	args starlark.Tuple,
-	kwargs []starlark.Tuple) (starlark.Value, error) {
-
-	w := b.Receiver().(*Image)
-	width, height := w.Size()
-
-	return starlark.Tuple([]starlark.Value{
-		starlark.MakeInt(width),
-		starlark.MakeInt(height),
-	}), nil
-}
-
-func imageFrameCount(
+func gaugeFrameCount(
 	thread *starlark.Thread,
 	b *starlark.Builtin,
 	args starlark.Tuple,
 	kwargs []starlark.Tuple) (starlark.Value, error) {
 
-	w := b.Receiver().(*Image)
+	w := b.Receiver().(*Gauge)
 	count := w.FrameCount()
 
 	return starlark.MakeInt(count), nil
 }
 
-type Marquee struct {
+type Grid struct {
 	Widget
 
-	render.Marquee
+	render.Grid
 
-	starlarkChild starlark.Value
+	starlarkRows *starlark.List
+
+	starlarkColumns *starlark.List
+
+	starlarkColumnAlign *starlark.List
+
+	starlarkRowSeparator starlark.String
+
+	starlarkColumnSeparator starlark.String
 
 	frame_count *starlark.Builtin
 }
 
-func newMarquee(
+func newGrid(
 	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
@@ -714,108 +1207,137 @@ func newMarquee(
 ) (starlark.Value, error) {
 
 	var (
-		child            starlark.Value
-		width            starlark.Int
-		height           starlark.Int
-		offset_start     starlark.Int
-		offset_end       starlark.Int
-		scroll_direction starlark.String
-		align            starlark.String
-		delay            starlark.Int
+		rows             *starlark.List
+		columns          *starlark.List
+		column_align     *starlark.List
+		cross_align      starlark.String
+		row_gap          starlark.Int
+		column_gap       starlark.Int
+		row_separator    starlark.String
+		column_separator starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
-		"Marquee",
+		"Grid",
 		args, kwargs,
-		"child", &child,
-		"width?", &width,
-		"height?", &height,
-		"offset_start?", &offset_start,
-		"offset_end?", &offset_end,
-		"scroll_direction?", &scroll_direction,
-		"align?", &align,
-		"delay?", &delay,
+		"rows", &rows,
+		"columns?", &columns,
+		"column_align?", &column_align,
+		"cross_align?", &cross_align,
+		"row_gap?", &row_gap,
+		"column_gap?", &column_gap,
+		"row_separator?", &row_separator,
+		"column_separator?", &column_separator,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for Marquee: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for Grid: %s", err)
 	}
 
-	w := &Marquee{}
+	w := &Grid{}
 
-	if child != nil {
-		childWidget, ok := child.(Widget)
-		if !ok {
-			return nil, fmt.Errorf(
-				"invalid type for child: %s (expected Widget)",
-				child.Type(),
-			)
-		}
-		w.Child = childWidget.AsRenderWidget()
-		w.starlarkChild = child
+	w.starlarkRows = rows
+	if val, err := WidgetRowsFromStarlark(rows); err == nil {
+		w.Rows = val
+	} else {
+		return nil, fmt.Errorf("rows: %s", err)
 	}
 
-	w.Width = int(width.BigInt().Int64())
+	if columns == nil {
+		columns = starlark.NewList(nil)
+	}
+	w.starlarkColumns = columns
+	if val, err := StringsFromStarlark(columns); err == nil {
+		w.Columns = val
+	} else {
+		return nil, fmt.Errorf("columns: %s", err)
+	}
 
-	w.Height = int(height.BigInt().Int64())
+	if column_align == nil {
+		column_align = starlark.NewList(nil)
+	}
+	w.starlarkColumnAlign = column_align
+	if val, err := StringsFromStarlark(column_align); err == nil {
+		w.ColumnAlign = val
+	} else {
+		return nil, fmt.Errorf("column_align: %s", err)
+	}
 
-	w.OffsetStart = int(offset_start.BigInt().Int64())
+	w.CrossAlign = cross_align.GoString()
 
-	w.OffsetEnd = int(offset_end.BigInt().Int64())
+	w.RowGap = int(row_gap.BigInt().Int64())
 
-	w.ScrollDirection = scroll_direction.GoString()
+	w.ColumnGap = int(column_gap.BigInt().Int64())
 
-	w.Align = align.GoString()
+	w.starlarkRowSeparator = row_separator
+	if row_separator.Len() > 0 {
+		c, err := render.ParseColor(row_separator.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("row_separator is not a valid hex string: %s", row_separator.String())
+		}
+		w.RowSeparator = c
+	}
 
-	w.Delay = int(delay.BigInt().Int64())
+	w.starlarkColumnSeparator = column_separator
+	if column_separator.Len() > 0 {
+		c, err := render.ParseColor(column_separator.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("column_separator is not a valid hex string: %s", column_separator.String())
+		}
+		w.ColumnSeparator = c
+	}
 
-	w.frame_count = starlark.NewBuiltin("frame_count", marqueeFrameCount)
+	w.frame_count = starlark.NewBuiltin("frame_count", gridFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
 
 	return w, nil
 }
 
-func (w *Marquee) AsRenderWidget() render.Widget {
-	return &w.Marquee
+func (w *Grid) AsRenderWidget() render.Widget {
+	return &w.Grid
 }
 
-func (w *Marquee) AttrNames() []string {
+func (w *Grid) AttrNames() []string {
 	return []string{
-		"child", "width", "height", "offset_start", "offset_end", "scroll_direction", "align", "delay",
+		"rows", "columns", "column_align", "cross_align", "row_gap", "column_gap", "row_separator", "column_separator",
 	}
 }
 
-func (w *Marquee) Attr(name string) (starlark.Value, error) {
+func (w *Grid) Attr(name string) (starlark.Value, error) {
 	switch name {
 
-	case "child":
+	case "rows":
 
-		return w.starlarkChild, nil
+		return w.starlarkRows, nil
 
-	case "width":
+	case "columns":
 
-		return starlark.MakeInt(int(w.Width)), nil
+		return w.starlarkColumns, nil
 
-	case "height":
+	case "column_align":
 
-		return starlark.MakeInt(int(w.Height)), nil
+		return w.starlarkColumnAlign, nil
 
-	case "offset_start":
+	case "cross_align":
 
-		return starlark.MakeInt(int(w.OffsetStart)), nil
+		return starlark.String(w.CrossAlign), nil
 
-	case "offset_end":
+	case "row_gap":
 
-		return starlark.MakeInt(int(w.OffsetEnd)), nil
+		return starlark.MakeInt(int(w.RowGap)), nil
 
-	case "scroll_direction":
+	case "column_gap":
 
-		return starlark.String(w.ScrollDirection), nil
+		return starlark.MakeInt(int(w.ColumnGap)), nil
 
-	case "align":
+	case "row_separator":
 
-		return starlark.String(w.Align), nil
+		return w.starlarkRowSeparator, nil
 
-	case "delay":
+	case "column_separator":
 
-		return starlark.MakeInt(int(w.Delay)), nil
+		return w.starlarkColumnSeparator, nil
 
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
@@ -825,43 +1347,39 @@ func (w *Marquee) Attr(name string) (starlark.Value, error) {
 	}
 }
 
-func (w *Marquee) String() string       { return "Marquee(...)" }
-func (w *Marquee) Type() string         { return "Marquee" }
-func (w *Marquee) Freeze()              {}
-func (w *Marquee) Truth() starlark.Bool { return true }
+func (w *Grid) String() string       { return "Grid(...)" }
+func (w *Grid) Type() string         { return "Grid" }
+func (w *Grid) Freeze()              {}
+func (w *Grid) Truth() starlark.Bool { return true }
 
-func (w *Marquee) Hash() (uint32, error) {
+func (w *Grid) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
-func marqueeFrameCount(
+func gridFrameCount(
 	thread *starlark.Thread,
 	b *starlark.Builtin,
 	args starlark.Tuple,
 	kwargs []starlark.Tuple) (starlark.Value, error) {
 
-	w := b.Receiver().(*Marquee)
+	w := b.Receiver().(*Grid)
 	count := w.FrameCount()
 
 	return starlark.MakeInt(count), nil
 }
 
-type Padding struct {
+type Hold struct {
 	Widget
 
-	render.Padding
+	render.Hold
 
 	starlarkChild starlark.Value
 
-	starlarkPad starlark.Value
-
-	starlarkColor starlark.String
-
 	frame_count *starlark.Builtin
 }
 
-func newPadding(
+func newHold(
 	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
@@ -869,24 +1387,20 @@ func newPadding(
 ) (starlark.Value, error) {
 
 	var (
-		child    starlark.Value
-		pad      starlark.Value
-		expanded starlark.Bool
-		color    starlark.String
+		child  starlark.Value
+		frames starlark.Int
 	)
 
 	if err := starlark.UnpackArgs(
-		"Padding",
+		"Hold",
 		args, kwargs,
 		"child", &child,
-		"pad?", &pad,
-		"expanded?", &expanded,
-		"color?", &color,
+		"frames", &frames,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for Padding: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for Hold: %s", err)
 	}
 
-	w := &Padding{}
+	w := &Hold{}
 
 	if child != nil {
 		childWidget, ok := child.(Widget)
@@ -900,82 +1414,33 @@ func newPadding(
 		w.starlarkChild = child
 	}
 
-	w.starlarkPad = pad
-	switch padVal := pad.(type) {
-	case starlark.Int:
-		padInt := int(padVal.BigInt().Int64())
-		w.Pad.Left = padInt
-		w.Pad.Top = padInt
-		w.Pad.Right = padInt
-		w.Pad.Bottom = padInt
-	case starlark.Tuple:
-		padList := []starlark.Value(padVal)
-		if len(padList) != 4 {
-			return nil, fmt.Errorf(
-				"pad tuple must hold 4 elements (left, top, right, bottom), found %d",
-				len(padList),
-			)
-		}
-		padListInt := make([]starlark.Int, 4)
-		for i := 0; i < 4; i++ {
-			pi, ok := padList[i].(starlark.Int)
-			if !ok {
-				return nil, fmt.Errorf("pad element %d is not int", i)
-			}
-			padListInt[i] = pi
-		}
-		w.Pad.Left = int(padListInt[0].BigInt().Int64())
-		w.Pad.Top = int(padListInt[1].BigInt().Int64())
-		w.Pad.Right = int(padListInt[2].BigInt().Int64())
-		w.Pad.Bottom = int(padListInt[3].BigInt().Int64())
-	default:
-		return nil, fmt.Errorf("pad must be int or 4-tuple of int")
-	}
-
-	w.Expanded = bool(expanded)
-
-	w.starlarkColor = color
-	if color.Len() > 0 {
-		c, err := render.ParseColor(color.GoString())
-		if err != nil {
-			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
-		}
-		w.Color = c
-	}
+	w.Frames = int(frames.BigInt().Int64())
 
-	w.frame_count = starlark.NewBuiltin("frame_count", paddingFrameCount)
+	w.frame_count = starlark.NewBuiltin("frame_count", holdFrameCount)
 
 	return w, nil
 }
 
-func (w *Padding) AsRenderWidget() render.Widget {
-	return &w.Padding
+func (w *Hold) AsRenderWidget() render.Widget {
+	return &w.Hold
 }
 
-func (w *Padding) AttrNames() []string {
+func (w *Hold) AttrNames() []string {
 	return []string{
-		"child", "pad", "expanded", "color",
+		"child", "frames",
 	}
 }
 
-func (w *Padding) Attr(name string) (starlark.Value, error) {
+func (w *Hold) Attr(name string) (starlark.Value, error) {
 	switch name {
 
 	case "child":
 
 		return w.starlarkChild, nil
 
-	case "pad":
-
-		return w.starlarkPad, nil
-
-	case "expanded":
-
-		return starlark.Bool(w.Expanded), nil
-
-	case "color":
+	case "frames":
 
-		return w.starlarkColor, nil
+		return starlark.MakeInt(int(w.Frames)), nil
 
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
@@ -985,41 +1450,49 @@ func (w *Padding) Attr(name string) (starlark.Value, error) {
 	}
 }
 
-func (w *Padding) String() string       { return "Padding(...)" }
-func (w *Padding) Type() string         { return "Padding" }
-func (w *Padding) Freeze()              {}
-func (w *Padding) Truth() starlark.Bool { return true }
+func (w *Hold) String() string       { return "Hold(...)" }
+func (w *Hold) Type() string         { return "Hold" }
+func (w *Hold) Freeze()              {}
+func (w *Hold) Truth() starlark.Bool { return true }
 
-func (w *Padding) Hash() (uint32, error) {
+func (w *Hold) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
-func paddingFrameCount(
+func holdFrameCount(
 	thread *starlark.Thread,
 	b *starlark.Builtin,
 	args starlark.Tuple,
 	kwargs []starlark.Tuple) (starlark.Value, error) {
 
-	w := b.Receiver().(*Padding)
+	w := b.Receiver().(*Hold)
 	count := w.FrameCount()
 
 	return starlark.MakeInt(count), nil
 }
 
-type PieChart struct {
+type Image struct {
 	Widget
 
-	render.PieChart
+	render.Image
 
-	starlarkColors *starlark.List
+	starlarkBrightness starlark.Value
 
-	starlarkWeights *starlark.List
+	starlarkContrast starlark.Value
+
+	starlarkSaturation starlark.Value
+
+	starlarkTint starlark.String
+
+	starlarkPalette *starlark.List
+
+	size *starlark.Builtin
 
 	frame_count *starlark.Builtin
 }
 
-func newPieChart(
+func newImage(
 	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
@@ -1027,68 +1500,188 @@ func newPieChart(
 ) (starlark.Value, error) {
 
 	var (
-		colors   *starlark.List
-		weights  *starlark.List
-		diameter starlark.Int
+		src        starlark.String
+		width      starlark.Int
+		height     starlark.Int
+		fit        starlark.String
+		resample   starlark.String
+		brightness starlark.Value
+		contrast   starlark.Value
+		saturation starlark.Value
+		grayscale  starlark.Bool
+		tint       starlark.String
+		dither     starlark.String
+		palette    *starlark.List
 	)
 
 	if err := starlark.UnpackArgs(
-		"PieChart",
+		"Image",
 		args, kwargs,
-		"colors", &colors,
-		"weights", &weights,
-		"diameter", &diameter,
+		"src", &src,
+		"width?", &width,
+		"height?", &height,
+		"fit?", &fit,
+		"resample?", &resample,
+		"brightness?", &brightness,
+		"contrast?", &contrast,
+		"saturation?", &saturation,
+		"grayscale?", &grayscale,
+		"tint?", &tint,
+		"dither?", &dither,
+		"palette?", &palette,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for PieChart: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for Image: %s", err)
 	}
 
-	w := &PieChart{}
+	w := &Image{}
 
-	w.starlarkColors = colors
-	if val, err := ColorSeriesFromStarlark(colors); err == nil {
-		w.Colors = val
-	} else {
-		return nil, err
+	w.Src = src.GoString()
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.Fit = fit.GoString()
+
+	w.Resample = resample.GoString()
+
+	if brightness == nil {
+		brightness = starlark.None
+	}
+	w.starlarkBrightness = brightness
+	if _, isNone := brightness.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkBrightness); ok {
+			w.Brightness = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkBrightness.String())
+		}
 	}
 
-	w.starlarkWeights = weights
-	if val, err := WeightsFromStarlark(weights); err == nil {
-		w.Weights = val
+	if contrast == nil {
+		contrast = starlark.None
+	}
+	w.starlarkContrast = contrast
+	if _, isNone := contrast.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkContrast); ok {
+			w.Contrast = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkContrast.String())
+		}
+	}
+
+	if saturation == nil {
+		saturation = starlark.None
+	}
+	w.starlarkSaturation = saturation
+	if _, isNone := saturation.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkSaturation); ok {
+			w.Saturation = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkSaturation.String())
+		}
+	}
+
+	w.Grayscale = bool(grayscale)
+
+	w.starlarkTint = tint
+	if tint.Len() > 0 {
+		c, err := render.ParseColor(tint.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("tint is not a valid hex string: %s", tint.String())
+		}
+		w.Tint = c
+	}
+
+	w.Dither = dither.GoString()
+
+	if palette == nil {
+		palette = starlark.NewList(nil)
+	}
+	w.starlarkPalette = palette
+	if val, err := ColorSeriesFromStarlark(palette); err == nil {
+		w.Palette = val
 	} else {
 		return nil, err
 	}
 
-	w.Diameter = int(diameter.BigInt().Int64())
+	w.size = starlark.NewBuiltin("size", imageSize)
 
-	w.frame_count = starlark.NewBuiltin("frame_count", piechartFrameCount)
+	w.frame_count = starlark.NewBuiltin("frame_count", imageFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
 
 	return w, nil
 }
 
-func (w *PieChart) AsRenderWidget() render.Widget {
-	return &w.PieChart
+func (w *Image) AsRenderWidget() render.Widget {
+	return &w.Image
 }
 
-func (w *PieChart) AttrNames() []string {
+func (w *Image) AttrNames() []string {
 	return []string{
-		"colors", "weights", "diameter",
+		"src", "width", "height", "fit", "resample", "brightness", "contrast", "saturation", "grayscale", "tint", "dither", "palette", "delay",
 	}
 }
 
-func (w *PieChart) Attr(name string) (starlark.Value, error) {
+func (w *Image) Attr(name string) (starlark.Value, error) {
 	switch name {
 
-	case "colors":
+	case "src":
 
-		return w.starlarkColors, nil
+		return starlark.String(w.Src), nil
 
-	case "weights":
+	case "width":
 
-		return w.starlarkWeights, nil
+		return starlark.MakeInt(int(w.Width)), nil
 
-	case "diameter":
+	case "height":
 
-		return starlark.MakeInt(int(w.Diameter)), nil
+		return starlark.MakeInt(int(w.Height)), nil
+
+	case "fit":
+
+		return starlark.String(w.Fit), nil
+
+	case "resample":
+
+		return starlark.String(w.Resample), nil
+
+	case "brightness":
+
+		return w.starlarkBrightness, nil
+
+	case "contrast":
+
+		return w.starlarkContrast, nil
+
+	case "saturation":
+
+		return w.starlarkSaturation, nil
+
+	case "grayscale":
+
+		return starlark.Bool(w.Grayscale), nil
+
+	case "tint":
+
+		return w.starlarkTint, nil
+
+	case "dither":
+
+		return starlark.String(w.Dither), nil
+
+	case "palette":
+
+		return w.starlarkPalette, nil
+
+	case "delay":
+
+		return starlark.MakeInt(int(w.Delay)), nil
+
+	case "size":
+		return w.size.BindReceiver(w), nil
 
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
@@ -1098,51 +1691,54 @@ func (w *PieChart) Attr(name string) (starlark.Value, error) {
 	}
 }
 
-func (w *PieChart) String() string       { return "PieChart(...)" }
-func (w *PieChart) Type() string         { return "PieChart" }
-func (w *PieChart) Freeze()              {}
-func (w *PieChart) Truth() starlark.Bool { return true }
+func (w *Image) String() string       { return "Image(...)" }
+func (w *Image) Type() string         { return "Image" }
+func (w *Image) Freeze()              {}
+func (w *Image) Truth() starlark.Bool { return true }
 
-func (w *PieChart) Hash() (uint32, error) {
+func (w *Image) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
-func piechartFrameCount(
+func imageSize(
 	thread *starlark.Thread,
 	b *starlark.Builtin,
 	args starlark.Tuple,
 	kwargs []starlark.Tuple) (starlark.Value, error) {
 
-	w := b.Receiver().(*PieChart)
+	w := b.Receiver().(*Image)
+	width, height := w.Size()
+
+	return starlark.Tuple([]starlark.Value{
+		starlark.MakeInt(width),
+		starlark.MakeInt(height),
+	}), nil
+}
+
+func imageFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Image)
 	count := w.FrameCount()
 
 	return starlark.MakeInt(count), nil
 }
 
-type Plot struct {
+type Line struct {
 	Widget
 
-	render.Plot
-
-	starlarkData *starlark.List
+	render.Line
 
 	starlarkColor starlark.String
 
-	starlarkColorInverted starlark.String
-
-	starlarkXLim starlark.Tuple
-
-	starlarkYLim starlark.Tuple
-
-	starlarkFillColor starlark.String
-
-	starlarkFillColorInverted starlark.String
-
 	frame_count *starlark.Builtin
 }
 
-func newPlot(
+func newLine(
 	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
@@ -1150,49 +1746,38 @@ func newPlot(
 ) (starlark.Value, error) {
 
 	var (
-		data                *starlark.List
-		width               starlark.Int
-		height              starlark.Int
-		color               starlark.String
-		color_inverted      starlark.String
-		x_lim               starlark.Tuple
-		y_lim               starlark.Tuple
-		fill                starlark.Bool
-		chart_type          starlark.String
-		fill_color          starlark.String
-		fill_color_inverted starlark.String
+		x1           starlark.Int
+		y1           starlark.Int
+		x2           starlark.Int
+		y2           starlark.Int
+		color        starlark.String
+		stroke_width starlark.Int
+		rendering    starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
-		"Plot",
+		"Line",
 		args, kwargs,
-		"data", &data,
-		"width", &width,
-		"height", &height,
+		"x1", &x1,
+		"y1", &y1,
+		"x2", &x2,
+		"y2", &y2,
 		"color?", &color,
-		"color_inverted?", &color_inverted,
-		"x_lim?", &x_lim,
-		"y_lim?", &y_lim,
-		"fill?", &fill,
-		"chart_type?", &chart_type,
-		"fill_color?", &fill_color,
-		"fill_color_inverted?", &fill_color_inverted,
+		"stroke_width?", &stroke_width,
+		"rendering?", &rendering,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for Plot: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for Line: %s", err)
 	}
 
-	w := &Plot{}
+	w := &Line{}
 
-	w.starlarkData = data
-	if val, err := DataSeriesFromStarlark(data); err == nil {
-		w.Data = val
-	} else {
-		return nil, err
-	}
+	w.X1 = int(x1.BigInt().Int64())
 
-	w.Width = int(width.BigInt().Int64())
+	w.Y1 = int(y1.BigInt().Int64())
 
-	w.Height = int(height.BigInt().Int64())
+	w.X2 = int(x2.BigInt().Int64())
+
+	w.Y2 = int(y2.BigInt().Int64())
 
 	w.starlarkColor = color
 	if color.Len() > 0 {
@@ -1203,72 +1788,1054 @@ func newPlot(
 		w.Color = c
 	}
 
-	w.starlarkColorInverted = color_inverted
-	if color_inverted.Len() > 0 {
-		c, err := render.ParseColor(color_inverted.GoString())
-		if err != nil {
-			return nil, fmt.Errorf("color_inverted is not a valid hex string: %s", color_inverted.String())
-		}
-		w.ColorInverted = c
-	}
+	w.StrokeWidth = int(stroke_width.BigInt().Int64())
 
-	w.starlarkXLim = x_lim
-	if val, err := DataPointFromStarlark(x_lim); err == nil {
-		w.XLim = val
-	} else {
-		return nil, err
-	}
+	w.Rendering = rendering.GoString()
 
-	w.starlarkYLim = y_lim
-	if val, err := DataPointFromStarlark(y_lim); err == nil {
-		w.YLim = val
-	} else {
+	w.frame_count = starlark.NewBuiltin("frame_count", lineFrameCount)
+
+	if err := w.Init(); err != nil {
 		return nil, err
 	}
 
-	w.Fill = bool(fill)
+	return w, nil
+}
+
+func (w *Line) AsRenderWidget() render.Widget {
+	return &w.Line
+}
+
+func (w *Line) AttrNames() []string {
+	return []string{
+		"x1", "y1", "x2", "y2", "color", "stroke_width", "rendering",
+	}
+}
+
+func (w *Line) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "x1":
+
+		return starlark.MakeInt(int(w.X1)), nil
+
+	case "y1":
+
+		return starlark.MakeInt(int(w.Y1)), nil
+
+	case "x2":
+
+		return starlark.MakeInt(int(w.X2)), nil
+
+	case "y2":
+
+		return starlark.MakeInt(int(w.Y2)), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "stroke_width":
+
+		return starlark.MakeInt(int(w.StrokeWidth)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Line) String() string       { return "Line(...)" }
+func (w *Line) Type() string         { return "Line" }
+func (w *Line) Freeze()              {}
+func (w *Line) Truth() starlark.Bool { return true }
+
+func (w *Line) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func lineFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Line)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type Marquee struct {
+	Widget
+
+	render.Marquee
+
+	starlarkChild starlark.Value
+
+	frame_count *starlark.Builtin
+}
+
+func newMarquee(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		child            starlark.Value
+		width            starlark.Int
+		height           starlark.Int
+		offset_start     starlark.Int
+		offset_end       starlark.Int
+		scroll_direction starlark.String
+		align            starlark.String
+		delay            starlark.Int
+	)
+
+	if err := starlark.UnpackArgs(
+		"Marquee",
+		args, kwargs,
+		"child", &child,
+		"width?", &width,
+		"height?", &height,
+		"offset_start?", &offset_start,
+		"offset_end?", &offset_end,
+		"scroll_direction?", &scroll_direction,
+		"align?", &align,
+		"delay?", &delay,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Marquee: %s", err)
+	}
+
+	w := &Marquee{}
+
+	if child != nil {
+		childWidget, ok := child.(Widget)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for child: %s (expected Widget)",
+				child.Type(),
+			)
+		}
+		w.Child = childWidget.AsRenderWidget()
+		w.starlarkChild = child
+	}
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.OffsetStart = int(offset_start.BigInt().Int64())
+
+	w.OffsetEnd = int(offset_end.BigInt().Int64())
+
+	w.ScrollDirection = scroll_direction.GoString()
+
+	w.Align = align.GoString()
+
+	w.Delay = int(delay.BigInt().Int64())
+
+	w.frame_count = starlark.NewBuiltin("frame_count", marqueeFrameCount)
+
+	return w, nil
+}
+
+func (w *Marquee) AsRenderWidget() render.Widget {
+	return &w.Marquee
+}
+
+func (w *Marquee) AttrNames() []string {
+	return []string{
+		"child", "width", "height", "offset_start", "offset_end", "scroll_direction", "align", "delay",
+	}
+}
+
+func (w *Marquee) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "child":
+
+		return w.starlarkChild, nil
+
+	case "width":
+
+		return starlark.MakeInt(int(w.Width)), nil
+
+	case "height":
+
+		return starlark.MakeInt(int(w.Height)), nil
+
+	case "offset_start":
+
+		return starlark.MakeInt(int(w.OffsetStart)), nil
+
+	case "offset_end":
+
+		return starlark.MakeInt(int(w.OffsetEnd)), nil
+
+	case "scroll_direction":
+
+		return starlark.String(w.ScrollDirection), nil
+
+	case "align":
+
+		return starlark.String(w.Align), nil
+
+	case "delay":
+
+		return starlark.MakeInt(int(w.Delay)), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Marquee) String() string       { return "Marquee(...)" }
+func (w *Marquee) Type() string         { return "Marquee" }
+func (w *Marquee) Freeze()              {}
+func (w *Marquee) Truth() starlark.Bool { return true }
+
+func (w *Marquee) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func marqueeFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Marquee)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type Padding struct {
+	Widget
+
+	render.Padding
+
+	starlarkChild starlark.Value
+
+	starlarkPad starlark.Value
+
+	starlarkColor starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newPadding(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		child    starlark.Value
+		pad      starlark.Value
+		expanded starlark.Bool
+		color    starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"Padding",
+		args, kwargs,
+		"child", &child,
+		"pad?", &pad,
+		"expanded?", &expanded,
+		"color?", &color,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Padding: %s", err)
+	}
+
+	w := &Padding{}
+
+	if child != nil {
+		childWidget, ok := child.(Widget)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for child: %s (expected Widget)",
+				child.Type(),
+			)
+		}
+		w.Child = childWidget.AsRenderWidget()
+		w.starlarkChild = child
+	}
+
+	w.starlarkPad = pad
+	switch padVal := pad.(type) {
+	case starlark.Int:
+		padInt := int(padVal.BigInt().Int64())
+		w.Pad.Left = padInt
+		w.Pad.Top = padInt
+		w.Pad.Right = padInt
+		w.Pad.Bottom = padInt
+	case starlark.Tuple:
+		padList := []starlark.Value(padVal)
+		if len(padList) != 4 {
+			return nil, fmt.Errorf(
+				"pad tuple must hold 4 elements (left, top, right, bottom), found %d",
+				len(padList),
+			)
+		}
+		padListInt := make([]starlark.Int, 4)
+		for i := 0; i < 4; i++ {
+			pi, ok := padList[i].(starlark.Int)
+			if !ok {
+				return nil, fmt.Errorf("pad element %d is not int", i)
+			}
+			padListInt[i] = pi
+		}
+		w.Pad.Left = int(padListInt[0].BigInt().Int64())
+		w.Pad.Top = int(padListInt[1].BigInt().Int64())
+		w.Pad.Right = int(padListInt[2].BigInt().Int64())
+		w.Pad.Bottom = int(padListInt[3].BigInt().Int64())
+	default:
+		return nil, fmt.Errorf("pad must be int or 4-tuple of int")
+	}
+
+	w.Expanded = bool(expanded)
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.frame_count = starlark.NewBuiltin("frame_count", paddingFrameCount)
+
+	return w, nil
+}
+
+func (w *Padding) AsRenderWidget() render.Widget {
+	return &w.Padding
+}
+
+func (w *Padding) AttrNames() []string {
+	return []string{
+		"child", "pad", "expanded", "color",
+	}
+}
+
+func (w *Padding) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "child":
+
+		return w.starlarkChild, nil
+
+	case "pad":
+
+		return w.starlarkPad, nil
+
+	case "expanded":
+
+		return starlark.Bool(w.Expanded), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Padding) String() string       { return "Padding(...)" }
+func (w *Padding) Type() string         { return "Padding" }
+func (w *Padding) Freeze()              {}
+func (w *Padding) Truth() starlark.Bool { return true }
+
+func (w *Padding) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func paddingFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Padding)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type PieChart struct {
+	Widget
+
+	render.PieChart
+
+	starlarkColors *starlark.List
+
+	starlarkWeights *starlark.List
+
+	frame_count *starlark.Builtin
+}
+
+func newPieChart(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		colors    *starlark.List
+		weights   *starlark.List
+		diameter  starlark.Int
+		rendering starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"PieChart",
+		args, kwargs,
+		"colors", &colors,
+		"weights", &weights,
+		"diameter", &diameter,
+		"rendering?", &rendering,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for PieChart: %s", err)
+	}
+
+	w := &PieChart{}
+
+	if colors == nil {
+		colors = starlark.NewList(nil)
+	}
+	w.starlarkColors = colors
+	if val, err := ColorSeriesFromStarlark(colors); err == nil {
+		w.Colors = val
+	} else {
+		return nil, err
+	}
+
+	w.starlarkWeights = weights
+	if val, err := WeightsFromStarlark(weights); err == nil {
+		w.Weights = val
+	} else {
+		return nil, err
+	}
+
+	w.Diameter = int(diameter.BigInt().Int64())
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", piechartFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *PieChart) AsRenderWidget() render.Widget {
+	return &w.PieChart
+}
+
+func (w *PieChart) AttrNames() []string {
+	return []string{
+		"colors", "weights", "diameter", "rendering",
+	}
+}
+
+func (w *PieChart) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "colors":
+
+		return w.starlarkColors, nil
+
+	case "weights":
+
+		return w.starlarkWeights, nil
+
+	case "diameter":
+
+		return starlark.MakeInt(int(w.Diameter)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *PieChart) String() string       { return "PieChart(...)" }
+func (w *PieChart) Type() string         { return "PieChart" }
+func (w *PieChart) Freeze()              {}
+func (w *PieChart) Truth() starlark.Bool { return true }
+
+func (w *PieChart) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func piechartFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*PieChart)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type Plot struct {
+	Widget
+
+	render.Plot
+
+	starlarkData *starlark.List
+
+	starlarkColor starlark.String
+
+	starlarkColorInverted starlark.String
+
+	starlarkXLim starlark.Tuple
+
+	starlarkYLim starlark.Tuple
+
+	starlarkFillColor starlark.String
+
+	starlarkFillColorInverted starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newPlot(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		data                *starlark.List
+		width               starlark.Int
+		height              starlark.Int
+		color               starlark.String
+		color_inverted      starlark.String
+		x_lim               starlark.Tuple
+		y_lim               starlark.Tuple
+		fill                starlark.Bool
+		chart_type          starlark.String
+		fill_color          starlark.String
+		fill_color_inverted starlark.String
+		rendering           starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"Plot",
+		args, kwargs,
+		"data", &data,
+		"width", &width,
+		"height", &height,
+		"color?", &color,
+		"color_inverted?", &color_inverted,
+		"x_lim?", &x_lim,
+		"y_lim?", &y_lim,
+		"fill?", &fill,
+		"chart_type?", &chart_type,
+		"fill_color?", &fill_color,
+		"fill_color_inverted?", &fill_color_inverted,
+		"rendering?", &rendering,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Plot: %s", err)
+	}
+
+	w := &Plot{}
+
+	w.starlarkData = data
+	if val, err := DataSeriesFromStarlark(data); err == nil {
+		w.Data = val
+	} else {
+		return nil, err
+	}
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.starlarkColorInverted = color_inverted
+	if color_inverted.Len() > 0 {
+		c, err := render.ParseColor(color_inverted.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color_inverted is not a valid hex string: %s", color_inverted.String())
+		}
+		w.ColorInverted = c
+	}
+
+	w.starlarkXLim = x_lim
+	if val, err := DataPointFromStarlark(x_lim); err == nil {
+		w.XLim = val
+	} else {
+		return nil, err
+	}
+
+	w.starlarkYLim = y_lim
+	if val, err := DataPointFromStarlark(y_lim); err == nil {
+		w.YLim = val
+	} else {
+		return nil, err
+	}
+
+	w.Fill = bool(fill)
 
 	w.ChartType = chart_type.GoString()
 
-	w.starlarkFillColor = fill_color
-	if fill_color.Len() > 0 {
-		c, err := render.ParseColor(fill_color.GoString())
+	w.starlarkFillColor = fill_color
+	if fill_color.Len() > 0 {
+		c, err := render.ParseColor(fill_color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("fill_color is not a valid hex string: %s", fill_color.String())
+		}
+		w.FillColor = c
+	}
+
+	w.starlarkFillColorInverted = fill_color_inverted
+	if fill_color_inverted.Len() > 0 {
+		c, err := render.ParseColor(fill_color_inverted.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("fill_color_inverted is not a valid hex string: %s", fill_color_inverted.String())
+		}
+		w.FillColorInverted = c
+	}
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", plotFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Plot) AsRenderWidget() render.Widget {
+	return &w.Plot
+}
+
+func (w *Plot) AttrNames() []string {
+	return []string{
+		"data", "width", "height", "color", "color_inverted", "x_lim", "y_lim", "fill", "chart_type", "fill_color", "fill_color_inverted", "rendering",
+	}
+}
+
+func (w *Plot) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "data":
+
+		return w.starlarkData, nil
+
+	case "width":
+
+		return starlark.MakeInt(int(w.Width)), nil
+
+	case "height":
+
+		return starlark.MakeInt(int(w.Height)), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "color_inverted":
+
+		return w.starlarkColorInverted, nil
+
+	case "x_lim":
+
+		return w.starlarkXLim, nil
+
+	case "y_lim":
+
+		return w.starlarkYLim, nil
+
+	case "fill":
+
+		return starlark.Bool(w.Fill), nil
+
+	case "chart_type":
+
+		return starlark.String(w.ChartType), nil
+
+	case "fill_color":
+
+		return w.starlarkFillColor, nil
+
+	case "fill_color_inverted":
+
+		return w.starlarkFillColorInverted, nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Plot) String() string       { return "Plot(...)" }
+func (w *Plot) Type() string         { return "Plot" }
+func (w *Plot) Freeze()              {}
+func (w *Plot) Truth() starlark.Bool { return true }
+
+func (w *Plot) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func plotFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Plot)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type Polygon struct {
+	Widget
+
+	render.Polygon
+
+	starlarkVertices *starlark.List
+
+	starlarkColor starlark.String
+
+	starlarkStrokeColor starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newPolygon(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		vertices     *starlark.List
+		color        starlark.String
+		stroke_color starlark.String
+		stroke_width starlark.Int
+		rendering    starlark.String
+	)
+
+	if err := starlark.UnpackArgs(
+		"Polygon",
+		args, kwargs,
+		"vertices", &vertices,
+		"color?", &color,
+		"stroke_color?", &stroke_color,
+		"stroke_width?", &stroke_width,
+		"rendering?", &rendering,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Polygon: %s", err)
+	}
+
+	w := &Polygon{}
+
+	w.starlarkVertices = vertices
+	if val, err := DataSeriesFromStarlark(vertices); err == nil {
+		w.Vertices = val
+	} else {
+		return nil, err
+	}
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.starlarkStrokeColor = stroke_color
+	if stroke_color.Len() > 0 {
+		c, err := render.ParseColor(stroke_color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("stroke_color is not a valid hex string: %s", stroke_color.String())
+		}
+		w.StrokeColor = c
+	}
+
+	w.StrokeWidth = int(stroke_width.BigInt().Int64())
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", polygonFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Polygon) AsRenderWidget() render.Widget {
+	return &w.Polygon
+}
+
+func (w *Polygon) AttrNames() []string {
+	return []string{
+		"vertices", "color", "stroke_color", "stroke_width", "rendering",
+	}
+}
+
+func (w *Polygon) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "vertices":
+
+		return w.starlarkVertices, nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "stroke_color":
+
+		return w.starlarkStrokeColor, nil
+
+	case "stroke_width":
+
+		return starlark.MakeInt(int(w.StrokeWidth)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Polygon) String() string       { return "Polygon(...)" }
+func (w *Polygon) Type() string         { return "Polygon" }
+func (w *Polygon) Freeze()              {}
+func (w *Polygon) Truth() starlark.Bool { return true }
+
+func (w *Polygon) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func polygonFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Polygon)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type ProgressBar struct {
+	Widget
+
+	render.ProgressBar
+
+	starlarkValue starlark.Value
+
+	starlarkMin starlark.Value
+
+	starlarkMax starlark.Value
+
+	starlarkColor starlark.String
+
+	starlarkBackgroundColor starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newProgressBar(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		value            starlark.Value
+		min              starlark.Value
+		max              starlark.Value
+		width            starlark.Int
+		height           starlark.Int
+		color            starlark.String
+		background_color starlark.String
+		vertical         starlark.Bool
+		segments         starlark.Int
+		segment_gap      starlark.Int
+		grow_frames      starlark.Int
+	)
+
+	if err := starlark.UnpackArgs(
+		"ProgressBar",
+		args, kwargs,
+		"value", &value,
+		"min?", &min,
+		"max?", &max,
+		"width?", &width,
+		"height?", &height,
+		"color?", &color,
+		"background_color?", &background_color,
+		"vertical?", &vertical,
+		"segments?", &segments,
+		"segment_gap?", &segment_gap,
+		"grow_frames?", &grow_frames,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for ProgressBar: %s", err)
+	}
+
+	w := &ProgressBar{}
+
+	w.starlarkValue = value
+	if val, ok := starlark.AsFloat(w.starlarkValue); ok {
+		w.Value = val
+	} else {
+		return nil, fmt.Errorf("expected number, but got: %s", w.starlarkValue.String())
+	}
+
+	if min == nil {
+		min = starlark.None
+	}
+	w.starlarkMin = min
+	if _, isNone := min.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkMin); ok {
+			w.Min = val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkMin.String())
+		}
+	}
+
+	if max == nil {
+		max = starlark.None
+	}
+	w.starlarkMax = max
+	if _, isNone := max.(starlark.NoneType); !isNone {
+		if val, ok := starlark.AsFloat(w.starlarkMax); ok {
+			w.Max = &val
+		} else {
+			return nil, fmt.Errorf("expected number, but got: %s", w.starlarkMax.String())
+		}
+	}
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
 		if err != nil {
-			return nil, fmt.Errorf("fill_color is not a valid hex string: %s", fill_color.String())
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
 		}
-		w.FillColor = c
+		w.Color = c
 	}
 
-	w.starlarkFillColorInverted = fill_color_inverted
-	if fill_color_inverted.Len() > 0 {
-		c, err := render.ParseColor(fill_color_inverted.GoString())
+	w.starlarkBackgroundColor = background_color
+	if background_color.Len() > 0 {
+		c, err := render.ParseColor(background_color.GoString())
 		if err != nil {
-			return nil, fmt.Errorf("fill_color_inverted is not a valid hex string: %s", fill_color_inverted.String())
+			return nil, fmt.Errorf("background_color is not a valid hex string: %s", background_color.String())
 		}
-		w.FillColorInverted = c
+		w.BackgroundColor = c
 	}
 
-	w.frame_count = starlark.NewBuiltin("frame_count", plotFrameCount)
+	w.Vertical = bool(vertical)
+
+	w.Segments = int(segments.BigInt().Int64())
+
+	w.SegmentGap = int(segment_gap.BigInt().Int64())
+
+	w.GrowFrames = int(grow_frames.BigInt().Int64())
+
+	w.frame_count = starlark.NewBuiltin("frame_count", progressbarFrameCount)
 
 	return w, nil
 }
 
-func (w *Plot) AsRenderWidget() render.Widget {
-	return &w.Plot
+func (w *ProgressBar) AsRenderWidget() render.Widget {
+	return &w.ProgressBar
 }
 
-func (w *Plot) AttrNames() []string {
+func (w *ProgressBar) AttrNames() []string {
 	return []string{
-		"data", "width", "height", "color", "color_inverted", "x_lim", "y_lim", "fill", "chart_type", "fill_color", "fill_color_inverted",
+		"value", "min", "max", "width", "height", "color", "background_color", "vertical", "segments", "segment_gap", "grow_frames",
 	}
 }
 
-func (w *Plot) Attr(name string) (starlark.Value, error) {
+func (w *ProgressBar) Attr(name string) (starlark.Value, error) {
 	switch name {
 
-	case "data":
+	case "value":
 
-		return w.starlarkData, nil
+		return w.starlarkValue, nil
+
+	case "min":
+
+		return w.starlarkMin, nil
+
+	case "max":
+
+		return w.starlarkMax, nil
 
 	case "width":
 
@@ -1282,71 +2849,209 @@ func (w *Plot) Attr(name string) (starlark.Value, error) {
 
 		return w.starlarkColor, nil
 
-	case "color_inverted":
+	case "background_color":
 
-		return w.starlarkColorInverted, nil
+		return w.starlarkBackgroundColor, nil
 
-	case "x_lim":
+	case "vertical":
 
-		return w.starlarkXLim, nil
+		return starlark.Bool(w.Vertical), nil
 
-	case "y_lim":
+	case "segments":
 
-		return w.starlarkYLim, nil
+		return starlark.MakeInt(int(w.Segments)), nil
 
-	case "fill":
+	case "segment_gap":
 
-		return starlark.Bool(w.Fill), nil
+		return starlark.MakeInt(int(w.SegmentGap)), nil
 
-	case "chart_type":
+	case "grow_frames":
 
-		return starlark.String(w.ChartType), nil
+		return starlark.MakeInt(int(w.GrowFrames)), nil
 
-	case "fill_color":
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
 
-		return w.starlarkFillColor, nil
+	default:
+		return nil, nil
+	}
+}
 
-	case "fill_color_inverted":
+func (w *ProgressBar) String() string       { return "ProgressBar(...)" }
+func (w *ProgressBar) Type() string         { return "ProgressBar" }
+func (w *ProgressBar) Freeze()              {}
+func (w *ProgressBar) Truth() starlark.Bool { return true }
 
-		return w.starlarkFillColorInverted, nil
+func (w *ProgressBar) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
 
-	case "frame_count":
-		return w.frame_count.BindReceiver(w), nil
+func progressbarFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*ProgressBar)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
+type Root struct {
+	render.Root
+
+	starlarkChild starlark.Value
+
+	starlarkTransition starlark.Value
+}
+
+func newRoot(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		child               starlark.Value
+		delay               starlark.Int
+		max_age             starlark.Int
+		show_full_animation starlark.Bool
+		rendering           starlark.String
+		transition          starlark.Value
+	)
+
+	if err := starlark.UnpackArgs(
+		"Root",
+		args, kwargs,
+		"child", &child,
+		"delay?", &delay,
+		"max_age?", &max_age,
+		"show_full_animation?", &show_full_animation,
+		"rendering?", &rendering,
+		"transition?", &transition,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Root: %s", err)
+	}
+
+	w := &Root{}
+
+	if child != nil {
+		childWidget, ok := child.(Widget)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for child: %s (expected Widget)",
+				child.Type(),
+			)
+		}
+		w.Child = childWidget.AsRenderWidget()
+		w.starlarkChild = child
+	}
+
+	if val, err := starlark.AsInt32(delay); err == nil {
+		w.Delay = int32(val)
+	} else {
+		return nil, err
+	}
+
+	if val, err := starlark.AsInt32(max_age); err == nil {
+		w.MaxAge = int32(val)
+	} else {
+		return nil, err
+	}
+
+	w.ShowFullAnimation = bool(show_full_animation)
+
+	w.Rendering = rendering.GoString()
+
+	if transition != nil {
+		transitionTransition, ok := transition.(Transitionable)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for transition: %s (expected Transition)",
+				transition.Type(),
+			)
+		}
+		w.Transition = transitionTransition.AsRenderTransition()
+		w.starlarkTransition = transition
+	}
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *Root) AsRenderRoot() render.Root {
+	return w.Root
+}
+
+func (w *Root) AttrNames() []string {
+	return []string{
+		"child", "delay", "max_age", "show_full_animation", "rendering", "transition",
+	}
+}
+
+func (w *Root) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "child":
+
+		return w.starlarkChild, nil
+
+	case "delay":
+
+		return starlark.MakeInt(int(w.Delay)), nil
+
+	case "max_age":
+
+		return starlark.MakeInt(int(w.MaxAge)), nil
+
+	case "show_full_animation":
+
+		return starlark.Bool(w.ShowFullAnimation), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "transition":
+
+		return w.starlarkTransition, nil
 
 	default:
 		return nil, nil
 	}
 }
 
-func (w *Plot) String() string       { return "Plot(...)" }
-func (w *Plot) Type() string         { return "Plot" }
-func (w *Plot) Freeze()              {}
-func (w *Plot) Truth() starlark.Bool { return true }
+func (w *Root) String() string       { return "Root(...)" }
+func (w *Root) Type() string         { return "Root" }
+func (w *Root) Freeze()              {}
+func (w *Root) Truth() starlark.Bool { return true }
 
-func (w *Plot) Hash() (uint32, error) {
+func (w *Root) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
-func plotFrameCount(
-	thread *starlark.Thread,
-	b *starlark.Builtin,
-	args starlark.Tuple,
-	kwargs []starlark.Tuple) (starlark.Value, error) {
+type RoundedBox struct {
+	Widget
 
-	w := b.Receiver().(*Plot)
-	count := w.FrameCount()
+	render.RoundedBox
 
-	return starlark.MakeInt(count), nil
-}
+	starlarkChild starlark.Value
 
-type Root struct {
-	render.Root
+	starlarkColor starlark.String
 
-	starlarkChild starlark.Value
+	starlarkStrokeColor starlark.String
+
+	frame_count *starlark.Builtin
 }
 
-func newRoot(
+func newRoundedBox(
 	thread *starlark.Thread,
 	_ *starlark.Builtin,
 	args starlark.Tuple,
@@ -1354,24 +3059,34 @@ func newRoot(
 ) (starlark.Value, error) {
 
 	var (
-		child               starlark.Value
-		delay               starlark.Int
-		max_age             starlark.Int
-		show_full_animation starlark.Bool
+		child        starlark.Value
+		width        starlark.Int
+		height       starlark.Int
+		radius       starlark.Int
+		padding      starlark.Int
+		color        starlark.String
+		stroke_color starlark.String
+		stroke_width starlark.Int
+		rendering    starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
-		"Root",
+		"RoundedBox",
 		args, kwargs,
-		"child", &child,
-		"delay?", &delay,
-		"max_age?", &max_age,
-		"show_full_animation?", &show_full_animation,
+		"child?", &child,
+		"width?", &width,
+		"height?", &height,
+		"radius?", &radius,
+		"padding?", &padding,
+		"color?", &color,
+		"stroke_color?", &stroke_color,
+		"stroke_width?", &stroke_width,
+		"rendering?", &rendering,
 	); err != nil {
-		return nil, fmt.Errorf("unpacking arguments for Root: %s", err)
+		return nil, fmt.Errorf("unpacking arguments for RoundedBox: %s", err)
 	}
 
-	w := &Root{}
+	w := &RoundedBox{}
 
 	if child != nil {
 		childWidget, ok := child.(Widget)
@@ -1385,67 +3100,124 @@ func newRoot(
 		w.starlarkChild = child
 	}
 
-	if val, err := starlark.AsInt32(delay); err == nil {
-		w.Delay = int32(val)
-	} else {
-		return nil, err
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.Radius = int(radius.BigInt().Int64())
+
+	w.Padding = int(padding.BigInt().Int64())
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
 	}
 
-	if val, err := starlark.AsInt32(max_age); err == nil {
-		w.MaxAge = int32(val)
-	} else {
-		return nil, err
+	w.starlarkStrokeColor = stroke_color
+	if stroke_color.Len() > 0 {
+		c, err := render.ParseColor(stroke_color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("stroke_color is not a valid hex string: %s", stroke_color.String())
+		}
+		w.StrokeColor = c
 	}
 
-	w.ShowFullAnimation = bool(show_full_animation)
+	w.StrokeWidth = int(stroke_width.BigInt().Int64())
+
+	w.Rendering = rendering.GoString()
+
+	w.frame_count = starlark.NewBuiltin("frame_count", roundedboxFrameCount)
+
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
 
 	return w, nil
 }
 
-func (w *Root) AsRenderRoot() render.Root {
-	return w.Root
+func (w *RoundedBox) AsRenderWidget() render.Widget {
+	return &w.RoundedBox
 }
 
-func (w *Root) AttrNames() []string {
+func (w *RoundedBox) AttrNames() []string {
 	return []string{
-		"child", "delay", "max_age", "show_full_animation",
+		"child", "width", "height", "radius", "padding", "color", "stroke_color", "stroke_width", "rendering",
 	}
 }
 
-func (w *Root) Attr(name string) (starlark.Value, error) {
+func (w *RoundedBox) Attr(name string) (starlark.Value, error) {
 	switch name {
 
 	case "child":
 
 		return w.starlarkChild, nil
 
-	case "delay":
+	case "width":
 
-		return starlark.MakeInt(int(w.Delay)), nil
+		return starlark.MakeInt(int(w.Width)), nil
 
-	case "max_age":
+	case "height":
 
-		return starlark.MakeInt(int(w.MaxAge)), nil
+		return starlark.MakeInt(int(w.Height)), nil
 
-	case "show_full_animation":
+	case "radius":
 
-		return starlark.Bool(w.ShowFullAnimation), nil
+		return starlark.MakeInt(int(w.Radius)), nil
+
+	case "padding":
+
+		return starlark.MakeInt(int(w.Padding)), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "stroke_color":
+
+		return w.starlarkStrokeColor, nil
+
+	case "stroke_width":
+
+		return starlark.MakeInt(int(w.StrokeWidth)), nil
+
+	case "rendering":
+
+		return starlark.String(w.Rendering), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
 
 	default:
 		return nil, nil
 	}
 }
 
-func (w *Root) String() string       { return "Root(...)" }
-func (w *Root) Type() string         { return "Root" }
-func (w *Root) Freeze()              {}
-func (w *Root) Truth() starlark.Bool { return true }
+func (w *RoundedBox) String() string       { return "RoundedBox(...)" }
+func (w *RoundedBox) Type() string         { return "RoundedBox" }
+func (w *RoundedBox) Freeze()              {}
+func (w *RoundedBox) Truth() starlark.Bool { return true }
 
-func (w *Root) Hash() (uint32, error) {
+func (w *RoundedBox) Hash() (uint32, error) {
 	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
 	return uint32(sum), err
 }
 
+func roundedboxFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*RoundedBox)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
 type Row struct {
 	Widget
 
@@ -1581,6 +3353,8 @@ type Sequence struct {
 
 	starlarkChildren *starlark.List
 
+	starlarkTransition starlark.Value
+
 	frame_count *starlark.Builtin
 }
 
@@ -1592,13 +3366,15 @@ func newSequence(
 ) (starlark.Value, error) {
 
 	var (
-		children *starlark.List
+		children   *starlark.List
+		transition starlark.Value
 	)
 
 	if err := starlark.UnpackArgs(
 		"Sequence",
 		args, kwargs,
 		"children", &children,
+		"transition?", &transition,
 	); err != nil {
 		return nil, fmt.Errorf("unpacking arguments for Sequence: %s", err)
 	}
@@ -1626,6 +3402,18 @@ func newSequence(
 	}
 	w.starlarkChildren = children
 
+	if transition != nil {
+		transitionTransition, ok := transition.(Transitionable)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid type for transition: %s (expected Transition)",
+				transition.Type(),
+			)
+		}
+		w.Transition = transitionTransition.AsRenderTransition()
+		w.starlarkTransition = transition
+	}
+
 	w.frame_count = starlark.NewBuiltin("frame_count", sequenceFrameCount)
 
 	return w, nil
@@ -1637,7 +3425,7 @@ func (w *Sequence) AsRenderWidget() render.Widget {
 
 func (w *Sequence) AttrNames() []string {
 	return []string{
-		"children",
+		"children", "transition",
 	}
 }
 
@@ -1648,6 +3436,10 @@ func (w *Sequence) Attr(name string) (starlark.Value, error) {
 
 		return w.starlarkChildren, nil
 
+	case "transition":
+
+		return w.starlarkTransition, nil
+
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
 
@@ -1678,6 +3470,154 @@ func sequenceFrameCount(
 	return starlark.MakeInt(count), nil
 }
 
+type Sparkline struct {
+	Widget
+
+	render.Sparkline
+
+	starlarkData *starlark.List
+
+	starlarkColor starlark.String
+
+	starlarkFillColor starlark.String
+
+	frame_count *starlark.Builtin
+}
+
+func newSparkline(
+	thread *starlark.Thread,
+	_ *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple,
+) (starlark.Value, error) {
+
+	var (
+		data        *starlark.List
+		width       starlark.Int
+		height      starlark.Int
+		color       starlark.String
+		fill_color  starlark.String
+		grow_frames starlark.Int
+	)
+
+	if err := starlark.UnpackArgs(
+		"Sparkline",
+		args, kwargs,
+		"data", &data,
+		"width", &width,
+		"height", &height,
+		"color?", &color,
+		"fill_color?", &fill_color,
+		"grow_frames?", &grow_frames,
+	); err != nil {
+		return nil, fmt.Errorf("unpacking arguments for Sparkline: %s", err)
+	}
+
+	w := &Sparkline{}
+
+	w.starlarkData = data
+	if val, err := WeightsFromStarlark(data); err == nil {
+		w.Data = val
+	} else {
+		return nil, err
+	}
+
+	w.Width = int(width.BigInt().Int64())
+
+	w.Height = int(height.BigInt().Int64())
+
+	w.starlarkColor = color
+	if color.Len() > 0 {
+		c, err := render.ParseColor(color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("color is not a valid hex string: %s", color.String())
+		}
+		w.Color = c
+	}
+
+	w.starlarkFillColor = fill_color
+	if fill_color.Len() > 0 {
+		c, err := render.ParseColor(fill_color.GoString())
+		if err != nil {
+			return nil, fmt.Errorf("fill_color is not a valid hex string: %s", fill_color.String())
+		}
+		w.FillColor = c
+	}
+
+	w.GrowFrames = int(grow_frames.BigInt().Int64())
+
+	w.frame_count = starlark.NewBuiltin("frame_count", sparklineFrameCount)
+
+	return w, nil
+}
+
+func (w *Sparkline) AsRenderWidget() render.Widget {
+	return &w.Sparkline
+}
+
+func (w *Sparkline) AttrNames() []string {
+	return []string{
+		"data", "width", "height", "color", "fill_color", "grow_frames",
+	}
+}
+
+func (w *Sparkline) Attr(name string) (starlark.Value, error) {
+	switch name {
+
+	case "data":
+
+		return w.starlarkData, nil
+
+	case "width":
+
+		return starlark.MakeInt(int(w.Width)), nil
+
+	case "height":
+
+		return starlark.MakeInt(int(w.Height)), nil
+
+	case "color":
+
+		return w.starlarkColor, nil
+
+	case "fill_color":
+
+		return w.starlarkFillColor, nil
+
+	case "grow_frames":
+
+		return starlark.MakeInt(int(w.GrowFrames)), nil
+
+	case "frame_count":
+		return w.frame_count.BindReceiver(w), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (w *Sparkline) String() string       { return "Sparkline(...)" }
+func (w *Sparkline) Type() string         { return "Sparkline" }
+func (w *Sparkline) Freeze()              {}
+func (w *Sparkline) Truth() starlark.Bool { return true }
+
+func (w *Sparkline) Hash() (uint32, error) {
+	sum, err := hashstructure.Hash(w, hashstructure.FormatV2, nil)
+	return uint32(sum), err
+}
+
+func sparklineFrameCount(
+	thread *starlark.Thread,
+	b *starlark.Builtin,
+	args starlark.Tuple,
+	kwargs []starlark.Tuple) (starlark.Value, error) {
+
+	w := b.Receiver().(*Sparkline)
+	count := w.FrameCount()
+
+	return starlark.MakeInt(count), nil
+}
+
 type Stack struct {
 	Widget
 
@@ -1697,12 +3637,14 @@ func newStack(
 
 	var (
 		children *starlark.List
+		blend    starlark.String
 	)
 
 	if err := starlark.UnpackArgs(
 		"Stack",
 		args, kwargs,
 		"children", &children,
+		"blend?", &blend,
 	); err != nil {
 		return nil, fmt.Errorf("unpacking arguments for Stack: %s", err)
 	}
@@ -1730,8 +3672,14 @@ func newStack(
 	}
 	w.starlarkChildren = children
 
+	w.Blend = blend.GoString()
+
 	w.frame_count = starlark.NewBuiltin("frame_count", stackFrameCount)
 
+	if err := w.Init(); err != nil {
+		return nil, err
+	}
+
 	return w, nil
 }
 
@@ -1741,7 +3689,7 @@ func (w *Stack) AsRenderWidget() render.Widget {
 
 func (w *Stack) AttrNames() []string {
 	return []string{
-		"children",
+		"children", "blend",
 	}
 }
 
@@ -1752,6 +3700,10 @@ func (w *Stack) Attr(name string) (starlark.Value, error) {
 
 		return w.starlarkChildren, nil
 
+	case "blend":
+
+		return starlark.String(w.Blend), nil
+
 	case "frame_count":
 		return w.frame_count.BindReceiver(w), nil
 
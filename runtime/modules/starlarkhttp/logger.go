@@ -0,0 +1,26 @@
+package starlarkhttp
+
+import (
+	"net/http"
+	"time"
+
+	"go.starlark.net/starlark"
+)
+
+const threadRequestLoggerKey = "tidbyt.dev/pixlet/runtime/modules/starlarkhttp/$logger"
+
+// RequestLogger is called once for every request made by a thread it's
+// attached to, when the request completes. Either res or err is nil.
+type RequestLogger func(req *http.Request, res *http.Response, duration time.Duration, err error)
+
+// AttachRequestLogger attaches a RequestLogger to a Starlark thread, so
+// that requests made by the thread are logged.
+func AttachRequestLogger(thread *starlark.Thread, logger RequestLogger) {
+	thread.SetLocal(threadRequestLoggerKey, logger)
+}
+
+func logRequest(thread *starlark.Thread, req *http.Request, res *http.Response, duration time.Duration, err error) {
+	if logger, ok := thread.Local(threadRequestLoggerKey).(RequestLogger); ok {
+		logger(req, res, duration, err)
+	}
+}
@@ -35,10 +35,13 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	util "github.com/qri-io/starlib/util"
 	"go.starlark.net/starlark"
 	"go.starlark.net/starlarkstruct"
+
+	"tidbyt.dev/pixlet/starlarkutil"
 )
 
 // AsString unquotes a starlark string value
@@ -136,7 +139,10 @@ func (m *Module) reqMethod(method string) func(thread *starlark.Thread, _ *starl
 			return nil, err
 		}
 
-		req, err := http.NewRequest(strings.ToUpper(method), rawurl, nil)
+		// The request carries the thread's context, so it's cancelled
+		// along with the app, and the HTTP client can see what it's
+		// running for.
+		req, err := http.NewRequestWithContext(starlarkutil.ThreadContext(thread), strings.ToUpper(method), rawurl, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -160,7 +166,9 @@ func (m *Module) reqMethod(method string) func(thread *starlark.Thread, _ *starl
 			return nil, err
 		}
 
+		start := time.Now()
 		res, err := m.cli.Do(req)
+		logRequest(thread, req, res, time.Since(start), err)
 		if err != nil {
 			return nil, err
 		}
@@ -28,6 +28,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/qri-io/starlib/testdata"
 	"go.starlark.net/starlark"
@@ -140,3 +141,38 @@ func TestSetBody(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	thread := &starlark.Thread{Name: "unittests/abc123", Load: testdata.NewLoader(starlarkhttp.LoadModule, starlarkhttp.ModuleName)}
+
+	var logged []string
+	starlarkhttp.AttachRequestLogger(thread, func(req *http.Request, res *http.Response, duration time.Duration, err error) {
+		if err != nil {
+			logged = append(logged, req.Method+" "+err.Error())
+		} else {
+			logged = append(logged, req.Method+" "+req.URL.String()+" "+res.Status)
+		}
+	})
+
+	_, err := starlark.ExecFile(thread, "test.star", `
+load("http.star", "http")
+http.get(url)
+http.post(url)
+`, starlark.StringDict{"url": starlark.String(ts.URL)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"GET " + ts.URL + " 418 I'm a teapot",
+		"POST " + ts.URL + " 418 I'm a teapot",
+	}
+	if strings.Join(logged, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected requests %q, got %q", expected, logged)
+	}
+}
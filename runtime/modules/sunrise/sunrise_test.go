@@ -3,6 +3,7 @@ package sunrise_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"tidbyt.dev/pixlet/runtime"
@@ -59,3 +60,22 @@ func TestSunrise(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, screens)
 }
+
+func TestSunriseAtSimulatedTime(t *testing.T) {
+	src := `
+load("time.star", "time")
+load("sunrise.star", "sunrise")
+
+def main():
+	expected = time.parse_time("2022-01-15T12:17:29", format = "2006-01-02T15:04:05")
+	if sunrise.sunrise(40.6781784, -73.9441579, time.now()) != expected:
+		fail("sunrise isn't on the simulated day")
+	return []
+`
+
+	app, err := runtime.NewApplet("sun.star", []byte(src), runtime.WithTime(time.Date(2022, 1, 15, 22, 40, 24, 0, time.UTC)))
+	assert.NoError(t, err)
+
+	_, err = app.Run(context.Background())
+	assert.NoError(t, err)
+}
@@ -105,6 +105,20 @@ assert(4 == imgGif.size()[1], "4 == imgGif.size()[1]")
 assert(1230 == imgGif.delay, "1230 == imgGif.delay")
 assert(4 == imgGif.frame_count(), "4 == imgGif.frame_count()")
 
+imgFiltered = render.Image(
+    src = png_src,
+    width = 8,
+    height = 4,
+    fit = "contain",
+    resample = "bilinear",
+    saturation = -0.5,
+    dither = "ordered",
+    palette = ["#000", "#f00"],
+)
+assert(8 == imgFiltered.size()[0], "8 == imgFiltered.size()[0]")
+assert(4 == imgFiltered.size()[1], "4 == imgFiltered.size()[1]")
+assert(imgFiltered.fit == "contain", "imgFiltered.fit == 'contain'")
+
 # Row and Column
 r1 = render.Row(
     expanded = True,
@@ -131,6 +145,81 @@ assert(r1.children[1].cross_align == "end", 'r1.children[1].cross_align == "end"
 assert(len(r1.children) == 2, "len(r1.children) == 2")
 assert(len(r1.children[1].children) == 2, "len(r1.children[1].children) == 2")
 
+# Grid
+g1 = render.Grid(
+    columns = ["1fr", 10, "auto"],
+    column_align = ["start", "end"],
+    row_gap = 1,
+    row_separator = "#333",
+    rows = [
+        [render.Text("a"), render.Text("b"), render.Text("c")],
+        [render.Text("d"), None],
+    ],
+)
+
+assert(len(g1.rows) == 2, "len(g1.rows) == 2")
+assert(g1.columns[1] == 10, "g1.columns[1] == 10")
+assert(g1.row_separator == "#333", 'g1.row_separator == "#333"')
+assert(g1.frame_count() == 1, "g1.frame_count() == 1")
+
+# BarChart and Sparkline
+bc = render.BarChart(
+    data = [3, -1, (1, 2), 4.5],
+    width = 20,
+    height = 10,
+    colors = ["#f00", "#0f0"],
+    grow_frames = 5,
+)
+assert(bc.frame_count() == 5, "bc.frame_count() == 5")
+assert(len(bc.data) == 4, "len(bc.data) == 4")
+
+sl = render.Sparkline(data = [1, 2, 3], width = 10, height = 5)
+assert(sl.frame_count() == 1, "sl.frame_count() == 1")
+
+# ProgressBar and Gauge
+pb = render.ProgressBar(value = 42, height = 4, segments = 5, segment_gap = 1)
+assert(pb.value == 42, "pb.value == 42")
+assert(pb.max == None, "pb.max == None")
+assert(pb.frame_count() == 1, "pb.frame_count() == 1")
+
+ga = render.Gauge(value = 0.25, max = 1, diameter = 20, style = "needle", grow_frames = 8)
+assert(ga.max == 1, "ga.max == 1")
+assert(ga.frame_count() == 8, "ga.frame_count() == 8")
+
+pn = render.ProgressBar(value = 1, min = None, max = None)
+assert(pn.min == None, "pn.min == None")
+assert(pn.max == None, "pn.max == None")
+
+# Rendering and blend modes
+ci = render.Circle(color = "#fff", diameter = 5, rendering = "crisp")
+assert(ci.rendering == "crisp", "ci.rendering == 'crisp'")
+
+st = render.Stack(children = [ci, ga], blend = "additive")
+assert(st.blend == "additive", "st.blend == 'additive'")
+
+rt = render.Root(child = st, rendering = "smooth")
+assert(rt.rendering == "smooth", "rt.rendering == 'smooth'")
+
+# Vector primitives
+ln = render.Line(x1 = 0, y1 = 0, x2 = 10, y2 = 5, stroke_width = 2)
+assert(ln.x2 == 10, "ln.x2 == 10")
+assert(ln.stroke_width == 2, "ln.stroke_width == 2")
+
+pg = render.Polygon(vertices = [(0, 0), (5, 0), (0, 5)], color = "#f00", stroke_color = "#fff")
+assert(len(pg.vertices) == 3, "len(pg.vertices) == 3")
+
+ac = render.Arc(diameter = 10, start = 0, end = 90.5)
+assert(ac.end == 90.5, "ac.end == 90.5")
+
+rb = render.RoundedBox(render.Text("hi"), radius = 3, stroke_color = "#0f0")
+assert(rb.radius == 3, "rb.radius == 3")
+assert(rb.frame_count() == 1, "rb.frame_count() == 1")
+
+# Hold
+ho = render.Hold(render.Text("wait"), frames = 20)
+assert(ho.frames == 20, "ho.frames == 20")
+assert(ho.frame_count() == 20, "ho.frame_count() == 20")
+
 def main():
     return render.Root(child=r1)
 `
@@ -143,6 +232,22 @@ func TestBigDotStar(t *testing.T) {
 	assert.NotNil(t, screens)
 }
 
+func TestRequiredFloatIsNotNone(t *testing.T) {
+	src := `
+load("render.star", "render")
+load("animation.star", "animation")
+
+def main():
+    animation.Rotate(angle = None)
+    return render.Root(child = render.Box())
+`
+	app, err := NewApplet("none.star", []byte(src))
+	require.NoError(t, err)
+
+	_, err = app.Run(context.Background())
+	assert.ErrorContains(t, err, "expected number, but got: None")
+}
+
 func TestBox(t *testing.T) {
 	const (
 		filename = "test_box.star"
@@ -0,0 +1,127 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// RunStats describes the work that went into running an applet and
+// rendering what it returned.
+//
+// The runtime fills in the script metrics of every run whose context
+// carries the stats, see WithRunStats. Painting and encoding happen
+// outside the runtime, so whoever encodes the roots fills in the rest.
+type RunStats struct {
+	// Starlark execution steps taken.
+	Steps uint64
+
+	// Wall time spent running Starlark, including waiting on HTTP.
+	ScriptTime time.Duration
+
+	// Wall time spent painting frames, and encoding them.
+	PaintTime  time.Duration
+	EncodeTime time.Duration
+
+	// HTTP requests made, and how the cache handled them.
+	HTTPRequests    int64
+	HTTPCacheHits   int64
+	HTTPCacheMisses int64
+
+	// Frames painted, and the size of the encoded image in bytes.
+	Frames int
+	Bytes  int
+}
+
+type runStatsKey struct{}
+
+// WithRunStats returns a context that collects the metrics of the runs
+// it's passed to into stats. Stats add up when several runs share
+// them.
+func WithRunStats(ctx context.Context, stats *RunStats) context.Context {
+	return context.WithValue(ctx, runStatsKey{}, stats)
+}
+
+func runStatsFromContext(ctx context.Context) *RunStats {
+	stats, _ := ctx.Value(runStatsKey{}).(*RunStats)
+	return stats
+}
+
+// Counts a request the HTTP client made, given its cache status. It
+// does nothing when there are no stats to collect into.
+func (s *RunStats) addHTTPRequest(cacheStatus string) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddInt64(&s.HTTPRequests, 1)
+	switch cacheStatus {
+	case "HIT":
+		atomic.AddInt64(&s.HTTPCacheHits, 1)
+	case "MISS":
+		atomic.AddInt64(&s.HTTPCacheMisses, 1)
+	}
+}
+
+// TotalTime returns the time spent running, painting and encoding.
+func (s *RunStats) TotalTime() time.Duration {
+	return s.ScriptTime + s.PaintTime + s.EncodeTime
+}
+
+// Write prints the stats in a human readable form.
+func (s *RunStats) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(
+		w,
+		"steps:   %d\n"+
+			"script:  %s\n"+
+			"paint:   %s\n"+
+			"encode:  %s\n"+
+			"total:   %s\n"+
+			"http:    %d requests (%d cache hits, %d misses)\n"+
+			"frames:  %d\n"+
+			"size:    %d bytes\n",
+		s.Steps,
+		s.ScriptTime.Round(time.Microsecond),
+		s.PaintTime.Round(time.Microsecond),
+		s.EncodeTime.Round(time.Microsecond),
+		s.TotalTime().Round(time.Microsecond),
+		s.HTTPRequests, s.HTTPCacheHits, s.HTTPCacheMisses,
+		s.Frames,
+		s.Bytes,
+	)
+	return err
+}
+
+// MarshalJSON encodes the stats with times in milliseconds.
+func (s *RunStats) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+
+	return json.Marshal(struct {
+		Steps           uint64  `json:"steps"`
+		ScriptTime      float64 `json:"script_ms"`
+		PaintTime       float64 `json:"paint_ms"`
+		EncodeTime      float64 `json:"encode_ms"`
+		TotalTime       float64 `json:"total_ms"`
+		HTTPRequests    int64   `json:"http_requests"`
+		HTTPCacheHits   int64   `json:"http_cache_hits"`
+		HTTPCacheMisses int64   `json:"http_cache_misses"`
+		Frames          int     `json:"frames"`
+		Bytes           int     `json:"bytes"`
+	}{
+		Steps:           s.Steps,
+		ScriptTime:      ms(s.ScriptTime),
+		PaintTime:       ms(s.PaintTime),
+		EncodeTime:      ms(s.EncodeTime),
+		TotalTime:       ms(s.TotalTime()),
+		HTTPRequests:    s.HTTPRequests,
+		HTTPCacheHits:   s.HTTPCacheHits,
+		HTTPCacheMisses: s.HTTPCacheMisses,
+		Frames:          s.Frames,
+		Bytes:           s.Bytes,
+	})
+}
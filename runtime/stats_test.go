@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStats(t *testing.T) {
+	src := `
+load("render.star", "render")
+def main():
+    n = 0
+    for i in range(100):
+        n += i
+    return render.Root(child = render.Text(str(n)))
+`
+	app, err := NewApplet("test.star", []byte(src))
+	require.NoError(t, err)
+
+	stats := &RunStats{}
+	_, err = app.Run(WithRunStats(context.Background(), stats))
+	require.NoError(t, err)
+	assert.Greater(t, stats.Steps, uint64(100))
+	assert.Greater(t, stats.ScriptTime.Nanoseconds(), int64(0))
+	assert.Equal(t, int64(0), stats.HTTPRequests)
+
+	// Stats add up over runs
+	steps := stats.Steps
+	_, err = app.Run(WithRunStats(context.Background(), stats))
+	require.NoError(t, err)
+	assert.Equal(t, 2*steps, stats.Steps)
+
+	// Runs without stats are fine too
+	_, err = app.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2*steps, stats.Steps)
+}
+
+func TestRunStatsHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	InitHTTP(NewInMemoryCache())
+
+	src := fmt.Sprintf(`
+load("http.star", "http")
+load("render.star", "render")
+def main():
+    http.get("%[1]s", ttl_seconds = 60)
+    http.get("%[1]s", ttl_seconds = 60)
+    http.put("%[1]s")
+    return render.Root(child = render.Box())
+`, server.URL)
+	app, err := NewApplet("test.star", []byte(src))
+	require.NoError(t, err)
+
+	stats := &RunStats{}
+	_, err = app.Run(WithRunStats(context.Background(), stats))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.HTTPRequests)
+	assert.Equal(t, int64(1), stats.HTTPCacheHits)
+	assert.Equal(t, int64(1), stats.HTTPCacheMisses)
+}
+
+func TestRunStatsJSON(t *testing.T) {
+	stats := &RunStats{
+		Steps:        42,
+		ScriptTime:   1500000,
+		PaintTime:    500000,
+		HTTPRequests: 2,
+		Frames:       3,
+		Bytes:        1024,
+	}
+
+	b, err := json.Marshal(stats)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, 42.0, decoded["steps"])
+	assert.Equal(t, 1.5, decoded["script_ms"])
+	assert.Equal(t, 0.5, decoded["paint_ms"])
+	assert.Equal(t, 2.0, decoded["total_ms"])
+	assert.Equal(t, 2.0, decoded["http_requests"])
+	assert.Equal(t, 3.0, decoded["frames"])
+	assert.Equal(t, 1024.0, decoded["bytes"])
+}
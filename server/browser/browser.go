@@ -3,10 +3,12 @@
 package browser
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"html"
 	"html/template"
 	"log"
 	"net/http"
@@ -17,6 +19,7 @@ import (
 	"tidbyt.dev/pixlet/dist"
 	"tidbyt.dev/pixlet/server/fanout"
 	"tidbyt.dev/pixlet/server/loader"
+	"tidbyt.dev/pixlet/server/metrics"
 )
 
 // Browser provides a structure for serving WebP or GIF images over websockets to
@@ -30,7 +33,8 @@ type Browser struct {
 	r          *mux.Router
 	tmpl       *template.Template
 	loader     *loader.Loader
-	serveGif   bool               // True if serving GIF, false if serving WebP
+	serveGif   bool   // True if serving GIF, false if serving WebP
+	base       string // Path the browser is served under, if not the root.
 }
 
 //go:embed preview-mask.png
@@ -44,11 +48,13 @@ var previewHTML string
 
 // previewData is used to populate the HTML template.
 type previewData struct {
-	Title  string    `json:"title"`
-	Image  string    `json:"img"`
+	Title string `json:"title"`
+	// Left out after an error, so that the last image stays up.
+	Image     string `json:"img,omitempty"`
 	ImageType string `json:"img_type"`
-	Watch  bool      `json:"-"`
-	Err    string    `json:"error,omitempty"`
+	Watch     bool   `json:"-"`
+	Base      string `json:"-"`
+	Err       string `json:"error,omitempty"`
 }
 type handlerRequest struct {
 	ID    string `json:"id"`
@@ -98,13 +104,33 @@ func NewBrowser(addr string, title string, watch bool, updateChan chan loader.Up
 	r.HandleFunc("/api/v1/preview.gif", b.imageHandler)
 	r.HandleFunc("/api/v1/push", b.pushHandler)
 	r.HandleFunc("/api/v1/schema", b.schemaHandler).Methods("GET")
+	r.HandleFunc("/api/v1/stats", b.statsHandler).Methods("GET")
+	r.HandleFunc("/api/v1/frames", b.framesHandler).Methods("GET")
+	r.HandleFunc("/api/v1/frames/{index:[0-9]+}.png", b.frameHandler).Methods("GET")
+	r.HandleFunc("/api/v1/fixtures", b.fixturesHandler).Methods("GET")
+	r.HandleFunc("/api/v1/compare", b.compareHandler).Methods("POST")
 	r.HandleFunc("/api/v1/handlers/{handler}", b.schemaHandlerHandler).Methods("POST")
 	r.HandleFunc("/api/v1/ws", b.websocketHandler)
+
 	b.r = r
 
 	return b, nil
 }
 
+// RegisterMetrics adds the metrics of the loader and the websocket
+// clients to m, labelled with app.
+func (b *Browser) RegisterMetrics(m *metrics.Metrics, app string) {
+	b.loader.RegisterMetrics(m, app)
+	m.GaugeFunc("pixlet_websocket_clients", "Number of connected websocket clients.", app, func() float64 {
+		return float64(b.fo.ClientCount())
+	})
+}
+
+// ServeMetrics serves m for scraping at /metrics.
+func (b *Browser) ServeMetrics(m *metrics.Metrics) {
+	b.r.Handle("/metrics", m.Handler()).Methods("GET")
+}
+
 // Run starts the server process and runs forever in a blocking fashion. The
 // main routines include an update watcher to process incomming changes to the
 // image and running the http handlers.
@@ -133,6 +159,13 @@ func (b *Browser) schemaHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(b.loader.GetSchema())
 }
 
+// Responds with the metrics of the last render, or null if nothing has
+// rendered yet.
+func (b *Browser) statsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(b.loader.Stats())
+}
+
 func (b *Browser) schemaHandlerHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	if _, ok := vars["handler"]; !ok {
@@ -259,14 +292,7 @@ func (b *Browser) updateWatcher() error {
 	for {
 		select {
 		case up := <-b.updateChan:
-			b.fo.Broadcast(
-				fanout.WebsocketEvent{
-					Type:      fanout.EventTypeImage,
-					Message:   up.Image,
-					ImageType: img_type,
-				},
-			)
-
+			// After an error, the last image stays up alongside it
 			if up.Err != nil {
 				b.fo.Broadcast(
 					fanout.WebsocketEvent{
@@ -274,6 +300,14 @@ func (b *Browser) updateWatcher() error {
 						Message: up.Err.Error(),
 					},
 				)
+			} else {
+				b.fo.Broadcast(
+					fanout.WebsocketEvent{
+						Type:      fanout.EventTypeImage,
+						Message:   up.Image,
+						ImageType: img_type,
+					},
+				)
 			}
 
 			if up.Schema != "" {
@@ -284,12 +318,30 @@ func (b *Browser) updateWatcher() error {
 					},
 				)
 			}
+
+			if len(up.Logs) > 0 {
+				logs, err := json.Marshal(up.Logs)
+				if err != nil {
+					log.Printf("error encoding logs: %v", err)
+					continue
+				}
+				b.fo.Broadcast(
+					fanout.WebsocketEvent{
+						Type:    fanout.EventTypeLog,
+						Message: string(logs),
+					},
+				)
+			}
 		}
 	}
 }
 func (b *Browser) rootHandler(w http.ResponseWriter, r *http.Request) {
+	// The React frontend resolves its assets and API requests against
+	// the page's base, so that it works under any path.
+	base := fmt.Sprintf(`<head><base href="%s/">`, html.EscapeString(b.base))
+
 	w.Header().Set("Content-Type", "text/html")
-	w.Write(dist.Index)
+	w.Write(bytes.Replace(dist.Index, []byte("<head>"), []byte(base), 1))
 }
 
 func (b *Browser) oldRootHandler(w http.ResponseWriter, r *http.Request) {
@@ -303,7 +355,8 @@ func (b *Browser) oldRootHandler(w http.ResponseWriter, r *http.Request) {
 	data := previewData{
 		Title: b.title,
 		Watch: b.watch,
-		Image:  img,
+		Image: img,
+		Base:  b.base,
 	}
 
 	if err != nil {
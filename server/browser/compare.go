@@ -0,0 +1,227 @@
+package browser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"tidbyt.dev/pixlet/schema"
+	"tidbyt.dev/pixlet/server/loader"
+)
+
+// CompareRequest asks for several variants of the app to be rendered
+// side by side. Starting from Config, a variant is made for every
+// saved fixture if Fixtures is set, or for every option of the field
+// called Field. Each of those is then rendered at every size in Sizes.
+type CompareRequest struct {
+	Config   map[string]string `json:"config"`
+	Fixtures bool              `json:"fixtures"`
+	Field    string            `json:"field"`
+	Sizes    []SizeJSON        `json:"sizes"`
+}
+
+type SizeJSON struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// CompareJSON holds the renders of every variant asked for, in order.
+type CompareJSON struct {
+	Variants []VariantJSON `json:"variants"`
+}
+
+type VariantJSON struct {
+	loader.Variant
+	Image     string            `json:"img"`
+	ImageType string            `json:"img_type"`
+	Err       string            `json:"error,omitempty"`
+	Logs      []loader.LogEntry `json:"logs,omitempty"`
+}
+
+// Upper limit on the number of variants in a comparison, since each of
+// them is a render of its own.
+const maxVariants = 64
+
+func (b *Browser) fixturesHandler(w http.ResponseWriter, r *http.Request) {
+	fixtures, err := b.loader.Fixtures()
+	if err != nil {
+		w.WriteHeader(500)
+		fmt.Fprintln(w, err)
+		return
+	}
+	if fixtures == nil {
+		fixtures = []loader.Fixture{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(fixtures)
+}
+
+func (b *Browser) compareHandler(w http.ResponseWriter, r *http.Request) {
+	req := &CompareRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(400)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	var fixtures []loader.Fixture
+	if req.Fixtures {
+		var err error
+		fixtures, err = b.loader.Fixtures()
+		if err != nil {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, err)
+			return
+		}
+	}
+
+	s := &schema.Schema{}
+	if req.Field != "" {
+		if err := json.Unmarshal(b.loader.GetSchema(), s); err != nil {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, err)
+			return
+		}
+	}
+
+	variants, err := compareVariants(req, fixtures, s)
+	if err != nil {
+		w.WriteHeader(400)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	// Variants are rendered one after the other by the loader, the
+	// same way as any other render.
+	data := CompareJSON{Variants: []VariantJSON{}}
+	for _, v := range variants {
+		up := b.loader.LoadVariant(v)
+
+		variant := VariantJSON{
+			Variant:   v,
+			Image:     up.Image,
+			ImageType: up.ImageType,
+			Logs:      up.Logs,
+		}
+		if up.Err != nil {
+			variant.Err = up.Err.Error()
+		}
+		data.Variants = append(data.Variants, variant)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// Returns the variants asked for by req, given the app's fixtures and
+// schema.
+func compareVariants(req *CompareRequest, fixtures []loader.Fixture, s *schema.Schema) ([]loader.Variant, error) {
+	if req.Fixtures && req.Field != "" {
+		return nil, fmt.Errorf("compare either fixtures or the options of a field, not both")
+	}
+
+	variants := []loader.Variant{{Config: mergeConfig(req.Config, nil)}}
+
+	switch {
+	case req.Fixtures:
+		if len(fixtures) == 0 {
+			return nil, fmt.Errorf("no fixtures found, save them in %s in the app's directory", loader.FixturesFile)
+		}
+
+		variants = nil
+		for _, f := range fixtures {
+			variants = append(variants, loader.Variant{
+				Name:   f.Name,
+				Config: mergeConfig(req.Config, f.Config),
+			})
+		}
+
+	case req.Field != "":
+		options, err := fieldOptions(s, req.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		variants = nil
+		for _, o := range options {
+			variants = append(variants, loader.Variant{
+				Name:   o.Display,
+				Config: mergeConfig(req.Config, map[string]string{req.Field: o.Value}),
+			})
+		}
+	}
+
+	if len(req.Sizes) > 0 {
+		var sized []loader.Variant
+		for _, v := range variants {
+			for _, size := range req.Sizes {
+				if size.Width <= 0 || size.Height <= 0 {
+					return nil, fmt.Errorf("invalid size %dx%d", size.Width, size.Height)
+				}
+
+				name := fmt.Sprintf("%dx%d", size.Width, size.Height)
+				if v.Name != "" {
+					name = v.Name + " " + name
+				}
+
+				sized = append(sized, loader.Variant{
+					Name:   name,
+					Config: v.Config,
+					Width:  size.Width,
+					Height: size.Height,
+				})
+			}
+		}
+		variants = sized
+	}
+
+	if len(variants) > maxVariants {
+		return nil, fmt.Errorf("too many variants to compare: %d, at most %d", len(variants), maxVariants)
+	}
+
+	return variants, nil
+}
+
+// Returns the options of a field in the schema, for fields that have
+// a fixed set of them.
+func fieldOptions(s *schema.Schema, id string) ([]schema.SchemaOption, error) {
+	for _, field := range s.Fields {
+		if field.ID != id {
+			continue
+		}
+
+		switch field.Type {
+		case "dropdown", "radio":
+			options := []schema.SchemaOption{}
+			for _, o := range field.Options {
+				if o.Display == "" {
+					o.Display = o.Text
+				}
+				options = append(options, o)
+			}
+			return options, nil
+		case "onoff":
+			return []schema.SchemaOption{
+				{Display: "on", Value: "true"},
+				{Display: "off", Value: "false"},
+			}, nil
+		default:
+			return nil, fmt.Errorf("field %s is a %s, only dropdown, radio and onoff fields can be compared", id, field.Type)
+		}
+	}
+
+	return nil, fmt.Errorf("no field %s in schema", id)
+}
+
+// Returns a copy of config with the values of override set.
+func mergeConfig(config, override map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range config {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,97 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tidbyt.dev/pixlet/schema"
+	"tidbyt.dev/pixlet/server/loader"
+)
+
+func TestCompareVariants(t *testing.T) {
+	base := map[string]string{"units": "metric", "city": "Stockholm"}
+
+	fixtures := []loader.Fixture{
+		{Name: "rain", Config: map[string]string{"weather": "rain"}},
+		{Name: "new york", Config: map[string]string{"city": "New York"}},
+	}
+
+	s := &schema.Schema{Fields: []schema.SchemaField{
+		{Type: "dropdown", ID: "units", Options: []schema.SchemaOption{
+			{Display: "Metric", Text: "Metric", Value: "metric"},
+			{Text: "Imperial", Value: "imperial"},
+		}},
+		{Type: "onoff", ID: "seconds"},
+		{Type: "text", ID: "city"},
+	}}
+
+	// Without anything to vary, the base config is rendered as is
+	variants, err := compareVariants(&CompareRequest{Config: base}, fixtures, s)
+	require.NoError(t, err)
+	assert.Equal(t, []loader.Variant{{Config: base}}, variants)
+
+	// Fixtures are applied on top of the base config
+	variants, err = compareVariants(&CompareRequest{Config: base, Fixtures: true}, fixtures, s)
+	require.NoError(t, err)
+	assert.Equal(t, []loader.Variant{
+		{Name: "rain", Config: map[string]string{"units": "metric", "city": "Stockholm", "weather": "rain"}},
+		{Name: "new york", Config: map[string]string{"units": "metric", "city": "New York"}},
+	}, variants)
+
+	variants, err = compareVariants(&CompareRequest{Config: base, Field: "units"}, fixtures, s)
+	require.NoError(t, err)
+	assert.Equal(t, []loader.Variant{
+		{Name: "Metric", Config: map[string]string{"units": "metric", "city": "Stockholm"}},
+		{Name: "Imperial", Config: map[string]string{"units": "imperial", "city": "Stockholm"}},
+	}, variants)
+
+	variants, err = compareVariants(&CompareRequest{Field: "seconds"}, fixtures, s)
+	require.NoError(t, err)
+	assert.Equal(t, []loader.Variant{
+		{Name: "on", Config: map[string]string{"seconds": "true"}},
+		{Name: "off", Config: map[string]string{"seconds": "false"}},
+	}, variants)
+
+	// Every variant is rendered at every size
+	variants, err = compareVariants(&CompareRequest{
+		Field: "seconds",
+		Sizes: []SizeJSON{{64, 32}, {128, 64}},
+	}, fixtures, s)
+	require.NoError(t, err)
+	assert.Equal(t, []loader.Variant{
+		{Name: "on 64x32", Config: map[string]string{"seconds": "true"}, Width: 64, Height: 32},
+		{Name: "on 128x64", Config: map[string]string{"seconds": "true"}, Width: 128, Height: 64},
+		{Name: "off 64x32", Config: map[string]string{"seconds": "false"}, Width: 64, Height: 32},
+		{Name: "off 128x64", Config: map[string]string{"seconds": "false"}, Width: 128, Height: 64},
+	}, variants)
+}
+
+func TestCompareVariantsErrors(t *testing.T) {
+	s := &schema.Schema{Fields: []schema.SchemaField{
+		{Type: "text", ID: "city"},
+	}}
+
+	_, err := compareVariants(&CompareRequest{Fixtures: true}, nil, s)
+	assert.ErrorContains(t, err, "no fixtures found")
+
+	_, err = compareVariants(&CompareRequest{Fixtures: true, Field: "city"}, nil, s)
+	assert.ErrorContains(t, err, "not both")
+
+	_, err = compareVariants(&CompareRequest{Field: "city"}, nil, s)
+	assert.ErrorContains(t, err, "only dropdown, radio and onoff")
+
+	_, err = compareVariants(&CompareRequest{Field: "units"}, nil, s)
+	assert.ErrorContains(t, err, "no field units")
+
+	_, err = compareVariants(&CompareRequest{Sizes: []SizeJSON{{0, 32}}}, nil, s)
+	assert.ErrorContains(t, err, "invalid size")
+
+	sizes := make([]SizeJSON, maxVariants+1)
+	for i := range sizes {
+		sizes[i] = SizeJSON{64, 32}
+	}
+	_, err = compareVariants(&CompareRequest{Sizes: sizes}, nil, s)
+	assert.ErrorContains(t, err, "too many variants")
+}
@@ -0,0 +1,125 @@
+package browser
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"tidbyt.dev/pixlet/render"
+)
+
+// FramesJSON describes the frames of the last render, for inspecting
+// them one by one. The frames themselves are served as PNGs at
+// /api/v1/frames/{index}.png. Pass layout=true to list the widgets
+// painted in each frame.
+type FramesJSON struct {
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	FrameCount int         `json:"frame_count"`
+	Frames     []FrameJSON `json:"frames"`
+}
+
+// FrameJSON is a single frame, with its delay in milliseconds and the
+// area its widgets painted in, in canvas pixels. If the layout is
+// requested, every widget painted in the frame is listed too.
+type FrameJSON struct {
+	Delay   int          `json:"delay"`
+	Bounds  BoundsJSON   `json:"bounds"`
+	Widgets []WidgetJSON `json:"widgets,omitempty"`
+}
+
+// WidgetJSON is a widget painted in a frame, and how deeply it's
+// nested.
+type WidgetJSON struct {
+	Type   string     `json:"type"`
+	Depth  int        `json:"depth"`
+	Bounds BoundsJSON `json:"bounds"`
+}
+
+type BoundsJSON struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func boundsJSON(r image.Rectangle) BoundsJSON {
+	return BoundsJSON{
+		X:      r.Min.X,
+		Y:      r.Min.Y,
+		Width:  r.Dx(),
+		Height: r.Dy(),
+	}
+}
+
+func (b *Browser) framesHandler(w http.ResponseWriter, r *http.Request) {
+	frames, err := b.loader.Frames()
+	if err != nil {
+		w.WriteHeader(500)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	width, height := b.loader.FrameSize()
+	data := FramesJSON{
+		Width:      width,
+		Height:     height,
+		FrameCount: len(frames),
+		Frames:     []FrameJSON{},
+	}
+	var layouts [][]render.WidgetBounds
+	if r.URL.Query().Get("layout") == "true" {
+		layouts = b.loader.Layouts()
+	}
+
+	for i, f := range frames {
+		frame := FrameJSON{
+			Delay:  f.Delay,
+			Bounds: boundsJSON(f.Bounds),
+		}
+		if i < len(layouts) {
+			for _, widget := range layouts[i] {
+				frame.Widgets = append(frame.Widgets, WidgetJSON{
+					Type:   widget.Type,
+					Depth:  widget.Depth,
+					Bounds: boundsJSON(widget.Bounds),
+				})
+			}
+		}
+		data.Frames = append(data.Frames, frame)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+func (b *Browser) frameHandler(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		w.WriteHeader(400)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	frames, err := b.loader.Frames()
+	if err != nil {
+		w.WriteHeader(500)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	if index < 0 || index >= len(frames) {
+		w.WriteHeader(404)
+		fmt.Fprintf(w, "frame %d out of range, have %d frames\n", index, len(frames))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	png.Encode(w, frames[index].Image)
+}
@@ -11,3 +11,8 @@ func (b *Browser) serveHTTP() error {
 	log.Printf("listening at http://%s\n", b.addr)
 	return http.ListenAndServe(b.addr, b.r)
 }
+
+func (ws *Workspace) serveHTTP() error {
+	log.Printf("listening at http://%s\n", ws.addr)
+	return http.ListenAndServe(ws.addr, ws.r)
+}
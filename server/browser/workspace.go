@@ -0,0 +1,151 @@
+package browser
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/sync/errgroup"
+
+	"tidbyt.dev/pixlet/server/metrics"
+)
+
+// WorkspaceApp is an app served as part of a workspace.
+type WorkspaceApp struct {
+	ID      string
+	Title   string
+	Browser *Browser
+}
+
+// Workspace serves several apps, each with its own browser under
+// /apps/{id}/, along with an index page listing them all.
+type Workspace struct {
+	addr  string
+	title string
+	watch bool
+	apps  []WorkspaceApp
+	r     *mux.Router
+	tmpl  *template.Template
+}
+
+//go:embed workspace.html
+var workspaceHTML string
+
+// workspaceData is used to populate the index template, and is
+// returned by the apps API.
+type workspaceData struct {
+	Title string             `json:"-"`
+	Watch bool               `json:"-"`
+	Apps  []workspaceAppData `json:"apps"`
+}
+
+type workspaceAppData struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+	Image string `json:"img"`
+}
+
+// NewWorkspace sets up a workspace of apps. Call Run() to kick off the
+// main loops.
+func NewWorkspace(addr string, title string, watch bool, apps []WorkspaceApp) (*Workspace, error) {
+	tmpl, err := template.New("workspace").Parse(workspaceHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{
+		addr:  addr,
+		title: title,
+		watch: watch,
+		apps:  apps,
+		tmpl:  tmpl,
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/", ws.indexHandler)
+	r.HandleFunc("/favicon.png", ws.faviconHandler).Methods("GET")
+	r.HandleFunc("/preview-mask.png", ws.previewMaskHandler).Methods("GET")
+	r.HandleFunc("/api/v1/apps", ws.appsHandler).Methods("GET")
+
+	for _, app := range apps {
+		base := appPath(app.ID)
+		app.Browser.base = base
+
+		r.Handle(base, http.RedirectHandler(base+"/", http.StatusMovedPermanently))
+		r.PathPrefix(base + "/").Handler(http.StripPrefix(base, app.Browser.r))
+	}
+	ws.r = r
+
+	return ws, nil
+}
+
+// ServeMetrics serves m, holding the metrics of every app, for scraping
+// at /metrics.
+func (ws *Workspace) ServeMetrics(m *metrics.Metrics) {
+	ws.r.Handle("/metrics", m.Handler()).Methods("GET")
+}
+
+// Returns the path an app is served under.
+func appPath(id string) string {
+	return "/apps/" + id
+}
+
+// Run starts the server process and runs forever in a blocking fashion. The
+// update watchers of all apps run alongside the http handlers.
+func (ws *Workspace) Run() error {
+	g := errgroup.Group{}
+
+	for _, app := range ws.apps {
+		defer app.Browser.fo.Quit()
+		g.Go(app.Browser.updateWatcher)
+	}
+	g.Go(ws.serveHTTP)
+
+	return g.Wait()
+}
+
+func (ws *Workspace) data() workspaceData {
+	data := workspaceData{
+		Title: ws.title,
+		Watch: ws.watch,
+	}
+
+	for _, app := range ws.apps {
+		img := "preview.webp"
+		if app.Browser.serveGif {
+			img = "preview.gif"
+		}
+
+		data.Apps = append(data.Apps, workspaceAppData{
+			ID:    app.ID,
+			Title: app.Title,
+			Path:  appPath(app.ID) + "/",
+			Image: appPath(app.ID) + "/api/v1/" + img,
+		})
+	}
+
+	return data
+}
+
+func (ws *Workspace) indexHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+	ws.tmpl.Execute(w, ws.data())
+}
+
+func (ws *Workspace) appsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ws.data())
+}
+
+func (ws *Workspace) faviconHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(favicon)
+}
+
+func (ws *Workspace) previewMaskHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(previewMask)
+}
@@ -12,6 +12,11 @@ const (
 	// EventTypeErr is used to signal there was an error encountered rendering
 	// the image.
 	EventTypeErr = "error"
+
+	// EventTypeLog is used to send output captured while rendering, such
+	// as prints, HTTP requests and render timing. The message is a JSON
+	// list of log entries.
+	EventTypeLog = "log"
 )
 
 // WebsocketEvent is a structure used to send messages over the socket.
@@ -1,5 +1,7 @@
 package fanout
 
+import "sync/atomic"
+
 // Fanout provides a structure for broadcasting messages to registered clients
 // when an update comes in on a go channel.
 type Fanout struct {
@@ -7,6 +9,7 @@ type Fanout struct {
 	quit       chan bool
 	register   chan *Client
 	unregister chan *Client
+	clients    atomic.Int64
 }
 
 // NewFanout creates a new Fanout structure and runs the main loop.
@@ -38,6 +41,11 @@ func (fo *Fanout) UnregisterClient(c *Client) {
 	fo.unregister <- c
 }
 
+// ClientCount returns the number of registered clients.
+func (fo *Fanout) ClientCount() int {
+	return int(fo.clients.Load())
+}
+
 // Quit stops broadcasting messages over the channel.
 func (fo *Fanout) Quit() {
 	fo.quit <- true
@@ -56,11 +64,13 @@ func (fo *Fanout) run() {
 			}
 		case c := <-fo.register:
 			clients[c] = true
+			fo.clients.Store(int64(len(clients)))
 		case c := <-fo.unregister:
 			if _, ok := clients[c]; ok {
 				delete(clients, c)
 				c.Quit()
 			}
+			fo.clients.Store(int64(len(clients)))
 		case broadcast := <-fo.broadcast:
 			for client := range clients {
 				client.Send(broadcast)
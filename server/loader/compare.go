@@ -0,0 +1,130 @@
+package loader
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"tidbyt.dev/pixlet/globals"
+	"tidbyt.dev/pixlet/render"
+	"tidbyt.dev/pixlet/runtime"
+)
+
+// FixturesFile is the file in an app's directory that saved configs,
+// or fixtures, are read from. It holds a list of named configs:
+//
+//	# fixtures.yaml
+//	- name: stockholm
+//	  config:
+//	    timezone: Europe/Stockholm
+//	- name: new york
+//	  config:
+//	    timezone: America/New_York
+const FixturesFile = "fixtures.yaml"
+
+// Fixture is a saved config for an app.
+type Fixture struct {
+	Name   string            `json:"name" yaml:"name"`
+	Config map[string]string `json:"config" yaml:"config"`
+}
+
+// Variant is one of several renders of an app compared side by side,
+// with its own config and display size. A zero width or height keeps
+// the current display size.
+type Variant struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config"`
+	Width  int               `json:"width"`
+	Height int               `json:"height"`
+}
+
+type variantRequest struct {
+	variant Variant
+	result  chan Update
+}
+
+// The display size is global to the process, so renders at another
+// size hold this exclusively, while every other render shares it.
+var displaySize sync.RWMutex
+
+// Fixtures returns the fixtures saved in the app's directory, or nil
+// if there are none. The file is read again on every call, so that
+// changes to it are picked up.
+func (l *Loader) Fixtures() ([]Fixture, error) {
+	if l.fs == nil {
+		return nil, nil
+	}
+
+	b, err := fs.ReadFile(l.fs, FixturesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", FixturesFile, err)
+	}
+
+	var fixtures []Fixture
+	if err := yaml.Unmarshal(b, &fixtures); err != nil {
+		return nil, fmt.Errorf("could not unmarshal %s: %w", FixturesFile, err)
+	}
+
+	for i, f := range fixtures {
+		if f.Name == "" {
+			return nil, fmt.Errorf("fixture %d in %s has no name", i+1, FixturesFile)
+		}
+	}
+
+	return fixtures, nil
+}
+
+// LoadVariant renders the app for a variant, and returns the image
+// along with anything logged while rendering it. Unlike LoadApplet,
+// the result isn't sent out as an update, and doesn't replace the last
+// render.
+func (l *Loader) LoadVariant(v Variant) Update {
+	req := variantRequest{
+		variant: v,
+		result:  make(chan Update, 1),
+	}
+	l.variantRequests <- req
+	return <-req.result
+}
+
+func (l *Loader) loadVariant(v Variant) Update {
+	if v.Width > 0 && v.Height > 0 {
+		displaySize.Lock()
+		defer displaySize.Unlock()
+
+		width, height := globals.Width, globals.Height
+		setDisplaySize(v.Width, v.Height)
+		defer setDisplaySize(width, height)
+	} else {
+		displaySize.RLock()
+		defer displaySize.RUnlock()
+	}
+
+	start := time.Now()
+	stats := &runtime.RunStats{}
+
+	_, img, err := l.renderApplet(v.Config, stats)
+	l.metrics.record(stats, time.Since(start), err)
+
+	up := Update{Err: err}
+	if err == nil {
+		up.Image = base64.StdEncoding.EncodeToString(img)
+		up.ImageType = l.imageType()
+	}
+	up.Logs = l.takeLogs()
+
+	return up
+}
+
+func setDisplaySize(width, height int) {
+	globals.Width, globals.Height = width, height
+	render.FrameWidth, render.FrameHeight = width, height
+}
@@ -7,30 +7,54 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"image"
 	"io/fs"
 	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"go.starlark.net/starlark"
+
 	"tidbyt.dev/pixlet/encode"
+	"tidbyt.dev/pixlet/playlist"
+	"tidbyt.dev/pixlet/render"
 	"tidbyt.dev/pixlet/runtime"
+	"tidbyt.dev/pixlet/runtime/modules/starlarkhttp"
 	"tidbyt.dev/pixlet/schema"
 )
 
 // Loader is a structure to provide applet loading when a file changes or on
 // demand.
 type Loader struct {
+	id               string
 	fs               fs.FS
 	fileChanges      chan bool
 	watch            bool
 	applet           runtime.Applet
 	configChanges    chan map[string]string
 	requestedChanges chan bool
+	variantRequests  chan variantRequest
 	updatesChan      chan Update
 	resultsChan      chan Update
 	maxDuration      int
 	initialLoad      chan bool
 	timeout          int
 	renderGif		 bool
+	filters          []encode.ImageFilter
+	opts             []runtime.AppletOption
+	playlistPath     string
+	logs             []LogEntry
+	logsMutex        sync.Mutex
+	stats            *runtime.RunStats
+	screens          *encode.Screens
+	frames           []encode.PaintedFrame
+	layouts          [][]render.WidgetBounds
+	frameSize        image.Point
+	lastRenderMutex  sync.Mutex
+	metrics          *loaderMetrics
 }
 
 type Update struct {
@@ -38,13 +62,33 @@ type Update struct {
 	ImageType string
 	Schema    string
 	Err       error
+	Logs      []LogEntry
+}
+
+// Kinds of log entries.
+const (
+	LogPrint  = "print"
+	LogHTTP   = "http"
+	LogRender = "render"
+	LogReload = "reload"
+)
+
+// LogEntry is a line of output from a render, such as a print() from
+// the app, an HTTP request it made, or how long rendering took.
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
 }
 
 // NewLoader instantiates a new loader structure. The loader will read off of
 // fileChanges channel and write updates to the updatesChan. Updates are base64
 // encoded WebP strings. If watch is enabled, both file changes and on demand
-// requests will send updates over the updatesChan.
+// requests will send updates over the updatesChan. The app is loaded with the
+// applet ID id, and run with opts. The HTTP and app caches must be initialized
+// beforehand, with runtime.InitHTTP and runtime.InitCache.
 func NewLoader(
+	id string,
 	fs fs.FS,
 	watch bool,
 	fileChanges chan bool,
@@ -52,37 +96,79 @@ func NewLoader(
 	maxDuration int,
 	timeout int,
 	renderGif bool,
+	filters []encode.ImageFilter,
+	opts ...runtime.AppletOption,
 ) (*Loader, error) {
-	l := &Loader{
-		fs:               fs,
+	l := newLoader(watch, fileChanges, updatesChan, maxDuration, timeout, renderGif, filters, opts)
+	l.id = id
+	l.fs = fs
+
+	if !l.watch {
+		app, err := loadScript(l.id, l.fs, l.appletOptions()...)
+		l.markInitialLoadComplete()
+		if err != nil {
+			return nil, err
+		} else {
+			l.applet = *app
+		}
+	}
+
+	return l, nil
+}
+
+// NewPlaylistLoader instantiates a loader for a playlist of apps, rendered
+// one after the other as a single image. The playlist file is read again on
+// every load, so that changes to it are picked up. Playlists have no schema.
+// As with NewLoader, the caches must be initialized beforehand.
+func NewPlaylistLoader(
+	path string,
+	watch bool,
+	fileChanges chan bool,
+	updatesChan chan Update,
+	maxDuration int,
+	timeout int,
+	renderGif bool,
+	filters []encode.ImageFilter,
+	opts ...runtime.AppletOption,
+) (*Loader, error) {
+	if _, err := playlist.LoadPlaylistFile(path); err != nil {
+		return nil, err
+	}
+
+	l := newLoader(watch, fileChanges, updatesChan, maxDuration, timeout, renderGif, filters, opts)
+	l.playlistPath = path
+	l.markInitialLoadComplete()
+
+	return l, nil
+}
+
+// Returns a loader with what's common to apps and playlists set up.
+func newLoader(
+	watch bool,
+	fileChanges chan bool,
+	updatesChan chan Update,
+	maxDuration int,
+	timeout int,
+	renderGif bool,
+	filters []encode.ImageFilter,
+	opts []runtime.AppletOption,
+) *Loader {
+	return &Loader{
 		fileChanges:      fileChanges,
 		watch:            watch,
 		applet:           runtime.Applet{},
 		updatesChan:      updatesChan,
 		configChanges:    make(chan map[string]string, 100),
 		requestedChanges: make(chan bool, 100),
+		variantRequests:  make(chan variantRequest),
 		resultsChan:      make(chan Update, 100),
 		maxDuration:      maxDuration,
 		initialLoad:      make(chan bool),
 		timeout:          timeout,
 		renderGif:        renderGif,
+		filters:          filters,
+		opts:             opts,
 	}
-
-	cache := runtime.NewInMemoryCache()
-	runtime.InitHTTP(cache)
-	runtime.InitCache(cache)
-
-	if !l.watch {
-		app, err := loadScript("app-id", l.fs)
-		l.markInitialLoadComplete()
-		if err != nil {
-			return nil, err
-		} else {
-			l.applet = *app
-		}
-	}
-
-	return l, nil
 }
 
 // Run executes the main loop. If there are config changes, those are recorded.
@@ -105,11 +191,9 @@ func (l *Loader) Run() error {
 				up.Err = err
 			} else {
 				up.Image = img
-				up.ImageType = "webp"
-				if l.renderGif {
-					up.ImageType = "gif"
-				}
+				up.ImageType = l.imageType()
 			}
+			up.Logs = l.takeLogs()
 
 			l.updatesChan <- up
 			l.resultsChan <- up
@@ -123,18 +207,26 @@ func (l *Loader) Run() error {
 				up.Err = err
 			} else {
 				up.Image = img
-				up.ImageType = "webp"
-				if l.renderGif {
-					up.ImageType = "gif"
-				}
+				up.ImageType = l.imageType()
 				up.Schema = string(l.applet.SchemaJSON)
 			}
+			up.Logs = l.takeLogs()
 
 			l.updatesChan <- up
+		case req := <-l.variantRequests:
+			req.result <- l.loadVariant(req.variant)
 		}
 	}
 }
 
+// Returns the type of image renders are encoded as.
+func (l *Loader) imageType() string {
+	if l.renderGif {
+		return "gif"
+	}
+	return "webp"
+}
+
 // LoadApplet loads the applet on demand.
 //
 // TODO: This method is thread safe, but has a pretty glaring race condition. If
@@ -163,48 +255,279 @@ func (l *Loader) GetSchema() []byte {
 
 func (l *Loader) CallSchemaHandler(ctx context.Context, handlerName, parameter string) (string, error) {
 	<-l.initialLoad
+	if l.playlistPath != "" {
+		return "", fmt.Errorf("playlists have no schema handlers")
+	}
 	return l.applet.CallSchemaHandler(ctx, handlerName, parameter)
 }
 
 func (l *Loader) loadApplet(config map[string]string) (string, error) {
-	if l.watch {
-		app, err := loadScript("app-id", l.fs)
-		l.markInitialLoadComplete()
-		if err != nil {
-			return "", err
-		} else {
-			l.applet = *app
-		}
+	displaySize.RLock()
+	defer displaySize.RUnlock()
+
+	start := time.Now()
+	stats := &runtime.RunStats{}
+
+	screens, img, err := l.renderApplet(config, stats)
+	l.metrics.record(stats, time.Since(start), err)
+	if err != nil {
+		return "", err
 	}
 
-	ctx, _ := context.WithTimeoutCause(
-		context.Background(),
-		time.Duration(l.timeout)*time.Millisecond,
-		fmt.Errorf("timeout after %dms", l.timeout),
-	)
+	l.lastRenderMutex.Lock()
+	l.stats = stats
+	l.screens = screens
+	l.frames = nil
+	l.layouts = nil
+	l.frameSize = image.Pt(render.FrameWidth, render.FrameHeight)
+	l.lastRenderMutex.Unlock()
 
-	roots, err := l.applet.RunWithConfig(ctx, config)
+	return base64.StdEncoding.EncodeToString(img), nil
+}
+
+// Runs the app or playlist, and encodes the result. Metrics of the
+// render are collected into stats.
+func (l *Loader) renderApplet(config map[string]string, stats *runtime.RunStats) (*encode.Screens, []byte, error) {
+	ctx := runtime.WithRunStats(context.Background(), stats)
+
+	var roots []render.Root
+	var err error
+	if l.playlistPath != "" {
+		roots, err = l.runPlaylist(ctx)
+	} else {
+		roots, err = l.runApplet(ctx, config)
+	}
 	if err != nil {
-		return "", fmt.Errorf("error running script: %w", err)
+		return nil, nil, err
 	}
 
 	screens := encode.ScreensFromRoots(roots)
 
+	// Playlists decide for themselves how long each app is shown
 	maxDuration := l.maxDuration
-	if screens.ShowFullAnimation {
+	if screens.ShowFullAnimation || l.playlistPath != "" {
 		maxDuration = 0
 	}
 
+	encodeStart := time.Now()
+
 	var img []byte
 	if l.renderGif {
-		img, err = screens.EncodeGIF(maxDuration)
+		img, err = screens.EncodeGIF(maxDuration, l.filters...)
 	} else {
-		img, err = screens.EncodeWebP(maxDuration)
+		img, err = screens.EncodeWebP(maxDuration, l.filters...)
 	}
 	if err != nil {
-		return "", fmt.Errorf("error rendering: %w", err)
+		return nil, nil, fmt.Errorf("error rendering: %w", err)
 	}
-	return base64.StdEncoding.EncodeToString(img), nil
+
+	stats.PaintTime = screens.PaintTime()
+	stats.EncodeTime = time.Since(encodeStart) - stats.PaintTime
+	stats.Frames = screens.FrameCount()
+	stats.Bytes = len(img)
+
+	l.log(LogRender, fmt.Sprintf(
+		"rendered in %s (script %s, paint %s, encode %s), %d frames, %d bytes",
+		stats.TotalTime().Round(time.Millisecond),
+		stats.ScriptTime.Round(time.Millisecond),
+		stats.PaintTime.Round(time.Millisecond),
+		stats.EncodeTime.Round(time.Millisecond),
+		stats.Frames,
+		stats.Bytes,
+	))
+
+	return screens, img, nil
+}
+
+// Stats returns the metrics of the last successful render, or nil if
+// there hasn't been one yet.
+func (l *Loader) Stats() *runtime.RunStats {
+	l.lastRenderMutex.Lock()
+	defer l.lastRenderMutex.Unlock()
+
+	return l.stats
+}
+
+// FrameSize returns the display size of the last successful render,
+// before filtering.
+func (l *Loader) FrameSize() (width, height int) {
+	l.lastRenderMutex.Lock()
+	defer l.lastRenderMutex.Unlock()
+
+	return l.frameSize.X, l.frameSize.Y
+}
+
+// Frames returns every frame of the last successful render, as it was
+// painted, or nil if there hasn't been one yet.
+func (l *Loader) Frames() ([]encode.PaintedFrame, error) {
+	// Bounds are worked out at the display size
+	displaySize.RLock()
+	defer displaySize.RUnlock()
+
+	l.lastRenderMutex.Lock()
+	defer l.lastRenderMutex.Unlock()
+
+	// Frames are filtered once, on the first request after a render
+	if l.frames == nil && l.screens != nil {
+		frames, err := l.screens.PaintedFrames(l.filters...)
+		if err != nil {
+			return nil, err
+		}
+		l.frames = frames
+	}
+
+	return l.frames, nil
+}
+
+// Layouts returns the type and bounds of every widget in each frame of
+// the last successful render, lined up with Frames.
+func (l *Loader) Layouts() [][]render.WidgetBounds {
+	// Layouts are painted at the display size
+	displaySize.RLock()
+	defer displaySize.RUnlock()
+
+	l.lastRenderMutex.Lock()
+	defer l.lastRenderMutex.Unlock()
+
+	// Layouts take another paint, so they're only recorded on demand
+	if l.layouts == nil && l.screens != nil {
+		l.layouts = l.screens.Layouts()
+	}
+
+	return l.layouts
+}
+
+// Options for running apps, which capture their output in the log.
+// Prints are still written to stdout as well.
+func (l *Loader) appletOptions() []runtime.AppletOption {
+	return append([]runtime.AppletOption{
+		runtime.WithPrintFunc(func(thread *starlark.Thread, msg string) {
+			fmt.Printf("[%s] %s\n", thread.Name, msg)
+			l.log(LogPrint, msg)
+		}),
+		runtime.WithThreadInitializer(func(thread *starlark.Thread) *starlark.Thread {
+			starlarkhttp.AttachRequestLogger(thread, l.logRequest)
+			return thread
+		}),
+	}, l.opts...)
+}
+
+func (l *Loader) logRequest(req *http.Request, res *http.Response, duration time.Duration, err error) {
+	if err != nil {
+		l.log(LogHTTP, fmt.Sprintf("%s %s failed after %s: %v", req.Method, req.URL, duration.Round(time.Millisecond), err))
+		return
+	}
+
+	msg := fmt.Sprintf("%s %s %s in %s", req.Method, req.URL, res.Status, duration.Round(time.Millisecond))
+	if status := res.Header.Get("tidbyt-cache-status"); status != "" {
+		msg += fmt.Sprintf(" (cache %s)", strings.ToLower(status))
+	}
+	l.log(LogHTTP, msg)
+}
+
+func (l *Loader) log(kind, message string) {
+	l.logsMutex.Lock()
+	defer l.logsMutex.Unlock()
+
+	l.logs = append(l.logs, LogEntry{
+		Time:    time.Now(),
+		Kind:    kind,
+		Message: message,
+	})
+}
+
+// Returns what's been logged since the last call.
+func (l *Loader) takeLogs() []LogEntry {
+	l.logsMutex.Lock()
+	defer l.logsMutex.Unlock()
+
+	logs := l.logs
+	l.logs = nil
+	return logs
+}
+
+func (l *Loader) runApplet(ctx context.Context, config map[string]string) ([]render.Root, error) {
+	if l.watch {
+		if err := l.reloadApplet(); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, _ = context.WithTimeoutCause(
+		ctx,
+		time.Duration(l.timeout)*time.Millisecond,
+		fmt.Errorf("timeout after %dms", l.timeout),
+	)
+
+	roots, err := l.applet.RunWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error running script: %w", err)
+	}
+
+	return roots, nil
+}
+
+// Loads the app again, to pick up changes to its files. Files that
+// didn't change, and load no file that did, aren't run again. If the
+// app fails to load, the previous version is kept, along with its
+// schema.
+func (l *Loader) reloadApplet() error {
+	opts := l.appletOptions()
+	if l.applet.MainFile != "" {
+		opts = append(opts, runtime.WithPrevious(&l.applet))
+	}
+
+	app, err := loadScript(l.id, l.fs, opts...)
+	l.markInitialLoadComplete()
+	if err != nil {
+		return fmt.Errorf("error loading script: %w", err)
+	}
+
+	// Only say so when some files changed, but not all of them
+	reused := app.ReusedPaths()
+	if len(reused) > 0 {
+		var changed []string
+		for _, path := range app.PathsForBundle() {
+			if strings.HasSuffix(path, ".star") && !slices.Contains(reused, path) {
+				changed = append(changed, path)
+			}
+		}
+		if len(changed) > 0 {
+			slices.Sort(changed)
+			l.log(LogReload, fmt.Sprintf(
+				"ran %s again, kept %s as unchanged",
+				strings.Join(changed, ", "),
+				strings.Join(reused, ", "),
+			))
+		}
+	}
+
+	if l.applet.MainFile != "" {
+		diff, err := schemaDiff(l.applet.SchemaJSON, app.SchemaJSON)
+		if err != nil {
+			log.Printf("error comparing schemas: %v", err)
+		}
+		for _, change := range diff {
+			l.log(LogReload, "schema "+change)
+		}
+	}
+
+	l.applet = *app
+	return nil
+}
+
+func (l *Loader) runPlaylist(ctx context.Context) ([]render.Root, error) {
+	p, err := playlist.LoadPlaylistFile(l.playlistPath)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := p.Roots(ctx, time.Duration(l.timeout)*time.Millisecond, l.appletOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("error running playlist: %w", err)
+	}
+
+	return roots, nil
 }
 
 func (l *Loader) markInitialLoadComplete() {
@@ -0,0 +1,45 @@
+package loader
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoaderAppletID(t *testing.T) {
+	fs := fstest.MapFS{
+		"app.star": {Data: []byte(`
+load("render.star", "render")
+
+def main():
+    return render.Root(child = render.Box())
+`)},
+	}
+
+	l, err := NewLoader("my-app", fs, false, make(chan bool), make(chan Update), 15000, 30000, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", l.applet.ID)
+}
+
+func TestFrameSize(t *testing.T) {
+	fs := fstest.MapFS{
+		"app.star": {Data: []byte(`
+load("render.star", "render")
+
+def main():
+    return render.Root(child = render.Box())
+`)},
+	}
+
+	l, err := NewLoader("my-app", fs, false, make(chan bool), make(chan Update), 15000, 30000, false, nil)
+	require.NoError(t, err)
+
+	_, err = l.loadApplet(map[string]string{})
+	require.NoError(t, err)
+
+	width, height := l.FrameSize()
+	assert.Equal(t, 64, width)
+	assert.Equal(t, 32, height)
+}
@@ -0,0 +1,68 @@
+package loader
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"tidbyt.dev/pixlet/runtime"
+	"tidbyt.dev/pixlet/server/metrics"
+)
+
+// loaderMetrics counts the renders done by a loader.
+type loaderMetrics struct {
+	renders         prometheus.Counter
+	renderErrors    prometheus.Counter
+	renderDuration  prometheus.Observer
+	httpRequests    prometheus.Counter
+	httpCacheHits   prometheus.Counter
+	httpCacheMisses prometheus.Counter
+
+	// Kept as well for the hit ratio, since counters can't be read
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// Records a render that took duration, and failed if err isn't nil.
+// Nothing is recorded unless metrics have been registered.
+func (m *loaderMetrics) record(stats *runtime.RunStats, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.renders.Inc()
+	if err != nil {
+		m.renderErrors.Inc()
+	}
+	m.renderDuration.Observe(duration.Seconds())
+
+	m.httpRequests.Add(float64(stats.HTTPRequests))
+	m.httpCacheHits.Add(float64(stats.HTTPCacheHits))
+	m.httpCacheMisses.Add(float64(stats.HTTPCacheMisses))
+	m.hits.Add(uint64(stats.HTTPCacheHits))
+	m.misses.Add(uint64(stats.HTTPCacheMisses))
+}
+
+// RegisterMetrics makes the loader record its renders and HTTP cache
+// use into m, labelled with app. Call it before Run.
+func (l *Loader) RegisterMetrics(m *metrics.Metrics, app string) {
+	lm := &loaderMetrics{
+		renders:         m.Renders.WithLabelValues(app),
+		renderErrors:    m.RenderErrors.WithLabelValues(app),
+		renderDuration:  m.RenderDuration.WithLabelValues(app),
+		httpRequests:    m.HTTPRequests.WithLabelValues(app),
+		httpCacheHits:   m.HTTPCacheHits.WithLabelValues(app),
+		httpCacheMisses: m.HTTPCacheMisses.WithLabelValues(app),
+	}
+
+	m.GaugeFunc("pixlet_http_cache_hit_ratio", "Share of cacheable HTTP requests served from the cache.", app, func() float64 {
+		hits, misses := lm.hits.Load(), lm.misses.Load()
+		if hits+misses == 0 {
+			return 0
+		}
+		return float64(hits) / float64(hits+misses)
+	})
+
+	l.metrics = lm
+}
@@ -0,0 +1,79 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Returns what changed from one schema to the next, one line for each
+// field added, removed or changed. Fields are told apart by their IDs.
+func schemaDiff(old, new []byte) ([]string, error) {
+	oldFields, err := schemaFields(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := schemaFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []string
+	for _, f := range oldFields {
+		if !slices.ContainsFunc(newFields, func(g map[string]any) bool { return g["id"] == f["id"] }) {
+			diff = append(diff, fmt.Sprintf("removed field %v", f["id"]))
+		}
+	}
+
+	for _, f := range newFields {
+		i := slices.IndexFunc(oldFields, func(g map[string]any) bool { return g["id"] == f["id"] })
+		if i < 0 {
+			diff = append(diff, fmt.Sprintf("added %v field %v", f["type"], f["id"]))
+			continue
+		}
+
+		if changed := changedKeys(oldFields[i], f); len(changed) > 0 {
+			diff = append(diff, fmt.Sprintf("changed %s of field %v", strings.Join(changed, ", "), f["id"]))
+		}
+	}
+
+	return diff, nil
+}
+
+// Returns the fields and notifications of a schema, as they're
+// serialized to JSON.
+func schemaFields(s []byte) ([]map[string]any, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	var fields struct {
+		Fields        []map[string]any `json:"schema"`
+		Notifications []map[string]any `json:"notifications"`
+	}
+	if err := json.Unmarshal(s, &fields); err != nil {
+		return nil, fmt.Errorf("decoding schema: %w", err)
+	}
+
+	return append(fields.Fields, fields.Notifications...), nil
+}
+
+// Returns the keys that are set to different values in a and b, in
+// order.
+func changedKeys(a, b map[string]any) []string {
+	var keys []string
+	for k, v := range a {
+		if !reflect.DeepEqual(v, b[k]) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	old := []byte(`{"version": "1", "schema": [
+		{"type": "text", "id": "city", "name": "City"},
+		{"type": "dropdown", "id": "units", "name": "Units", "default": "metric", "options": [
+			{"display": "Metric", "text": "Metric", "value": "metric"}
+		]},
+		{"type": "onoff", "id": "seconds", "name": "Seconds", "default": "false"}
+	]}`)
+
+	new := []byte(`{"version": "1", "schema": [
+		{"type": "text", "id": "city", "name": "City"},
+		{"type": "dropdown", "id": "units", "name": "Units", "default": "imperial", "options": [
+			{"display": "Metric", "text": "Metric", "value": "metric"},
+			{"display": "Imperial", "text": "Imperial", "value": "imperial"}
+		]},
+		{"type": "color", "id": "color", "name": "Color", "description": "Text color"}
+	]}`)
+
+	diff, err := schemaDiff(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"removed field seconds",
+		"changed default, options of field units",
+		"added color field color",
+	}, diff)
+
+	// Apps may have no schema at all
+	diff, err = schemaDiff(nil, new)
+	require.NoError(t, err)
+	assert.Len(t, diff, 3)
+
+	diff, err = schemaDiff(new, new)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	_, err = schemaDiff(old, []byte("{"))
+	assert.Error(t, err)
+}
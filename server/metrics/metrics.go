@@ -0,0 +1,77 @@
+// Package metrics holds the Prometheus metrics of a server. Series
+// for each app are labelled with the app's ID.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Upper bounds of the render duration buckets, in seconds.
+var renderDurationBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics are the metrics of every app served, along with those of
+// the Go runtime and the process.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	Renders         *prometheus.CounterVec
+	RenderErrors    *prometheus.CounterVec
+	RenderDuration  *prometheus.HistogramVec
+	HTTPRequests    *prometheus.CounterVec
+	HTTPCacheHits   *prometheus.CounterVec
+	HTTPCacheMisses *prometheus.CounterVec
+}
+
+// New creates the metrics of a server, in a registry of their own.
+func New() *Metrics {
+	counter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, []string{"app"})
+	}
+
+	m := &Metrics{
+		registry:     prometheus.NewRegistry(),
+		Renders:      counter("pixlet_renders_total", "Number of renders, including failed ones."),
+		RenderErrors: counter("pixlet_render_errors_total", "Number of renders that failed."),
+		RenderDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pixlet_render_duration_seconds",
+			Help:    "Time taken to run, paint and encode an app.",
+			Buckets: renderDurationBuckets,
+		}, []string{"app"}),
+		HTTPRequests:    counter("pixlet_http_requests_total", "Number of HTTP requests made by apps."),
+		HTTPCacheHits:   counter("pixlet_http_cache_hits_total", "Number of HTTP requests served from the cache."),
+		HTTPCacheMisses: counter("pixlet_http_cache_misses_total", "Number of cacheable HTTP requests not found in the cache."),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.Renders,
+		m.RenderErrors,
+		m.RenderDuration,
+		m.HTTPRequests,
+		m.HTTPCacheHits,
+		m.HTTPCacheMisses,
+	)
+
+	return m
+}
+
+// GaugeFunc adds a gauge for app, whose value is read from fn when
+// it's scraped. Every app's gauge of the same name must have the same
+// help.
+func (m *Metrics) GaugeFunc(name, help, app string, fn func() float64) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        name,
+		Help:        help,
+		ConstLabels: prometheus.Labels{"app": app},
+	}, fn))
+}
+
+// Handler serves the metrics for scraping.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
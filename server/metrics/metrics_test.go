@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsLabelledByApp(t *testing.T) {
+	m := New()
+
+	m.Renders.WithLabelValues("clock").Inc()
+	m.Renders.WithLabelValues("weather").Add(2)
+	m.RenderDuration.WithLabelValues("clock").Observe(0.02)
+	m.GaugeFunc("clients", "Number of clients.", "clock", func() float64 { return 1 })
+	m.GaugeFunc("clients", "Number of clients.", "weather", func() float64 { return 3 })
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	out := string(body)
+
+	assert.Contains(t, out, `pixlet_renders_total{app="clock"} 1`)
+	assert.Contains(t, out, `pixlet_renders_total{app="weather"} 2`)
+	assert.Contains(t, out, `pixlet_render_duration_seconds_bucket{app="clock",le="0.025"} 1`)
+	assert.Contains(t, out, `clients{app="clock"} 1`)
+	assert.Contains(t, out, `clients{app="weather"} 3`)
+
+	// Runtime metrics aren't per app, so they're only written once.
+	assert.Equal(t, 1, strings.Count(out, "\ngo_goroutines "))
+}
@@ -8,22 +8,62 @@ import (
 	"strings"
 
 	"golang.org/x/sync/errgroup"
+	"tidbyt.dev/pixlet/encode"
+	"tidbyt.dev/pixlet/playlist"
+	"tidbyt.dev/pixlet/runtime"
 	"tidbyt.dev/pixlet/server/browser"
 	"tidbyt.dev/pixlet/server/loader"
+	"tidbyt.dev/pixlet/server/metrics"
 	"tidbyt.dev/pixlet/tools"
 )
 
 // Server provides functionality to serve Starlark over HTTP. It has
 // functionality to watch a file and hot reload the browser on changes.
 type Server struct {
-	watcher *Watcher
-	browser *browser.Browser
-	loader  *loader.Loader
-	watch   bool
+	watchers []*Watcher
+	browser  runner
+	loaders  []*loader.Loader
+	watch    bool
 }
 
-// NewServer creates a new server initialized with the applet.
-func NewServer(host string, port int, watch bool, path string, maxDuration int, timeout int, serveGif bool) (*Server, error) {
+// runner is implemented by browser.Browser and browser.Workspace.
+type runner interface {
+	Run() error
+}
+
+// Options configure how a server serves apps.
+type Options struct {
+	Host  string
+	Port  int
+	Watch bool // Reload apps when their files change.
+
+	MaxDuration int // Maximum animation duration, in milliseconds.
+	Timeout     int // Maximum execution time, in milliseconds.
+	ServeGif    bool
+	Filters     []encode.ImageFilter
+
+	// If set, the path given to NewServer is a playlist file, and all
+	// apps in it are served as one image.
+	Playlist bool
+
+	// If set, metrics are served for scraping at /metrics, with each
+	// app's series labelled by its ID.
+	Metrics bool
+
+	// Options that apps are run with.
+	AppletOptions []runtime.AppletOption
+}
+
+func (o Options) addr() string {
+	return fmt.Sprintf("%s:%d", o.Host, o.Port)
+}
+
+// NewServer creates a new server initialized with the applet at path.
+func NewServer(path string, opts Options) (*Server, error) {
+	if opts.Playlist {
+		return newPlaylistServer(path, opts)
+	}
+
 	fileChanges := make(chan bool, 100)
 
 	// check if path exists, and whether it is a directory or a file
@@ -46,35 +86,171 @@ func NewServer(host string, port int, watch bool, path string, maxDuration int,
 		w = NewWatcher(path, fileChanges)
 	}
 
+	initCache()
+
+	updatesChan := make(chan loader.Update, 100)
+	l, err := loader.NewLoader("app-id", fs, opts.Watch, fileChanges, updatesChan, opts.MaxDuration, opts.Timeout, opts.ServeGif, opts.Filters, opts.AppletOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := browser.NewBrowser(opts.addr(), filepath.Base(path), opts.Watch, updatesChan, l, opts.ServeGif)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Metrics {
+		m := metrics.New()
+		b.RegisterMetrics(m, appName(path))
+		b.ServeMetrics(m)
+	}
+
+	return &Server{
+		watchers: []*Watcher{w},
+		browser:  b,
+		loaders:  []*loader.Loader{l},
+		watch:    opts.Watch,
+	}, nil
+}
+
+func newPlaylistServer(path string, opts Options) (*Server, error) {
+	fileChanges := make(chan bool, 100)
+
+	p, err := playlist.LoadPlaylistFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The playlist and every app in it are watched. Apps added to
+	// the playlist later on are only watched after a restart.
+	watchers := []*Watcher{NewWatcher(path, fileChanges)}
+	for _, appPath := range p.Paths() {
+		watchers = append(watchers, NewWatcher(appPath, fileChanges))
+	}
+
+	initCache()
+
 	updatesChan := make(chan loader.Update, 100)
-	l, err := loader.NewLoader(fs, watch, fileChanges, updatesChan, maxDuration, timeout, serveGif)
+	l, err := loader.NewPlaylistLoader(path, opts.Watch, fileChanges, updatesChan, opts.MaxDuration, opts.Timeout, opts.ServeGif, opts.Filters, opts.AppletOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	addr := fmt.Sprintf("%s:%d", host, port)
-	b, err := browser.NewBrowser(addr, filepath.Base(path), watch, updatesChan, l, serveGif)
+	b, err := browser.NewBrowser(opts.addr(), filepath.Base(path), opts.Watch, updatesChan, l, opts.ServeGif)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.Metrics {
+		m := metrics.New()
+		b.RegisterMetrics(m, appName(path))
+		b.ServeMetrics(m)
+	}
+
 	return &Server{
-		watcher: w,
-		browser: b,
-		loader:  l,
-		watch:   watch,
+		watchers: watchers,
+		browser:  b,
+		loaders:  []*loader.Loader{l},
+		watch:    opts.Watch,
 	}, nil
 }
 
+// NewWorkspaceServer creates a server for every app found in the
+// workspace at dir. Each app is served under /apps/{id}/ with its own
+// loader, schema and websocket, and an index of all apps is served
+// at the root. If opts.Metrics is set, the metrics of all apps are
+// served together at /metrics, labelled with their IDs. opts.Playlist
+// is ignored.
+func NewWorkspaceServer(dir string, opts Options) (*Server, error) {
+	apps, err := discoverApps(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// All apps share the cache. Their entries are kept apart by their
+	// IDs, which are part of the cache keys.
+	initCache()
+
+	var m *metrics.Metrics
+	if opts.Metrics {
+		m = metrics.New()
+	}
+
+	var watchers []*Watcher
+	var loaders []*loader.Loader
+	var browserApps []browser.WorkspaceApp
+	for _, app := range apps {
+		fileChanges := make(chan bool, 100)
+		updatesChan := make(chan loader.Update, 100)
+
+		l, err := loader.NewLoader(app.id, os.DirFS(app.path), opts.Watch, fileChanges, updatesChan, opts.MaxDuration, opts.Timeout, opts.ServeGif, opts.Filters, opts.AppletOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", app.path, err)
+		}
+
+		b, err := browser.NewBrowser(opts.addr(), app.title, opts.Watch, updatesChan, l, opts.ServeGif)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			b.RegisterMetrics(m, app.id)
+		}
+
+		watchers = append(watchers, NewWatcher(app.path, fileChanges))
+		loaders = append(loaders, l)
+		browserApps = append(browserApps, browser.WorkspaceApp{
+			ID:      app.id,
+			Title:   app.title,
+			Browser: b,
+		})
+	}
+
+	ws, err := browser.NewWorkspace(opts.addr(), filepath.Base(dir), opts.Watch, browserApps)
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		ws.ServeMetrics(m)
+	}
+
+	return &Server{
+		watchers: watchers,
+		browser:  ws,
+		loaders:  loaders,
+		watch:    opts.Watch,
+	}, nil
+}
+
+// Returns the name an app or playlist at path is labelled with in
+// metrics.
+func appName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// Sets up the cache used by apps, for HTTP requests and the cache
+// module. It lives as long as the server, so that it's kept when apps
+// are reloaded.
+func initCache() {
+	cache := runtime.NewInMemoryCache()
+	runtime.InitHTTP(cache)
+	runtime.InitCache(cache)
+}
+
 // Run serves the http server and runs forever in a blocking fashion.
 func (s *Server) Run() error {
 	g := errgroup.Group{}
 
-	g.Go(s.loader.Run)
+	for _, l := range s.loaders {
+		g.Go(l.Run)
+	}
 	g.Go(s.browser.Run)
 	if s.watch {
-		g.Go(s.watcher.Run)
-		s.loader.LoadApplet(make(map[string]string))
+		for _, w := range s.watchers {
+			g.Go(w.Run)
+		}
+		for _, l := range s.loaders {
+			l.LoadApplet(make(map[string]string))
+		}
 	}
 
 	return g.Wait()
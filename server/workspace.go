@@ -0,0 +1,138 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"tidbyt.dev/pixlet/manifest"
+)
+
+// workspaceApp is an app found in a workspace.
+type workspaceApp struct {
+	id    string
+	title string
+	path  string
+}
+
+// Matches the definition of an app's main function.
+var mainFuncRE = regexp.MustCompile(`(?m)^def\s+main\s*\(`)
+
+// discoverApps finds every app in the workspace at dir. A directory
+// is an app if it has a manifest, or a .star file defining main().
+// Directories inside an app belong to it, so they aren't searched,
+// and neither are hidden directories.
+func discoverApps(dir string) ([]workspaceApp, error) {
+	var apps []workspaceApp
+	paths := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		app, ok, err := loadWorkspaceApp(dir, path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if other, ok := paths[app.id]; ok {
+			return fmt.Errorf("apps in %s and %s have the same id: %s", other, path, app.id)
+		}
+		paths[app.id] = path
+		apps = append(apps, app)
+
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovering apps: %w", err)
+	}
+
+	if len(apps) == 0 {
+		return nil, fmt.Errorf("no apps found in %s", dir)
+	}
+
+	return apps, nil
+}
+
+// Loads the app in the directory at path, if there is one. Apps are
+// identified by their manifest, and otherwise by their path within
+// the workspace.
+func loadWorkspaceApp(root, path string) (workspaceApp, bool, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return workspaceApp{}, false, err
+	}
+
+	hasManifest, hasMain := false, false
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if e.Name() == manifest.ManifestFileName {
+			hasManifest = true
+		} else if strings.HasSuffix(e.Name(), ".star") && !hasMain {
+			src, err := os.ReadFile(filepath.Join(path, e.Name()))
+			if err != nil {
+				return workspaceApp{}, false, err
+			}
+			hasMain = mainFuncRE.Match(src)
+		}
+	}
+
+	if !hasManifest && !hasMain {
+		return workspaceApp{}, false, nil
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return workspaceApp{}, false, err
+	}
+	if rel == "." {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return workspaceApp{}, false, err
+		}
+		rel = filepath.Base(abs)
+	}
+
+	app := workspaceApp{
+		id:    manifest.GenerateID(strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")),
+		title: filepath.Base(rel),
+		path:  path,
+	}
+
+	if hasManifest {
+		f, err := os.Open(filepath.Join(path, manifest.ManifestFileName))
+		if err != nil {
+			return workspaceApp{}, false, err
+		}
+		defer f.Close()
+
+		m, err := manifest.LoadManifest(f)
+		if err != nil {
+			return workspaceApp{}, false, fmt.Errorf("%s: %w", path, err)
+		}
+		if m.ID != "" {
+			app.id = m.ID
+		}
+		if m.Name != "" {
+			app.title = m.Name
+		}
+	}
+
+	return app, true, nil
+}
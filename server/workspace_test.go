@@ -0,0 +1,59 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestDiscoverApps(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"clock/clock.star":            "def main():\n    pass\n",
+		"clock/assets/extra.star":     "def main():\n    pass\n",
+		"games/Chess Club/chess.star": "def main(config):\n    pass\n",
+		"weather/manifest.yaml":       "id: weather-now\nname: Weather Now\n",
+		"weather/weather.star":        "def main():\n    pass\n",
+		"lib/util.star":               "def helper():\n    pass\n",
+		".hidden/app.star":            "def main():\n    pass\n",
+		"notes/README.md":             "not an app",
+	})
+
+	apps, err := discoverApps(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []workspaceApp{
+		{id: "clock", title: "clock", path: filepath.Join(dir, "clock")},
+		{id: "games-chess-club", title: "Chess Club", path: filepath.Join(dir, "games/Chess Club")},
+		{id: "weather-now", title: "Weather Now", path: filepath.Join(dir, "weather")},
+	}, apps)
+}
+
+func TestDiscoverAppsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"lib/util.star": "def helper():\n    pass\n",
+	})
+
+	_, err := discoverApps(dir)
+	assert.ErrorContains(t, err, "no apps found")
+
+	writeFiles(t, dir, map[string]string{
+		"a/manifest.yaml": "id: same\n",
+		"b/manifest.yaml": "id: same\n",
+	})
+
+	_, err = discoverApps(dir)
+	assert.ErrorContains(t, err, "same id")
+}
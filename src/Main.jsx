@@ -6,9 +6,12 @@ import Box from '@mui/material/Box';
 import Grid from '@mui/material/Grid';
 
 import AppBar from './features/appbar/AppBar';
+import Compare from './features/compare/Compare';
 import ConfigManager from './features/config/ConfigManager';
+import Console from './features/console/Console';
 import ErrorManager from './features/errors/ErrorManager';
 import ErrorSnackbar from './features/errors/ErrorSnackbar';
+import Inspector from './features/inspector/Inspector';
 import ParamSetter from './features/config/ParamSetter';
 import Preview from './features/preview/Preview';
 import Schema from './features/schema/Schema';
@@ -39,6 +42,9 @@ export default function Main() {
                         <Grid item xs={12} lg={size}>
                             <Preview scale={10} />
                             <Controls />
+                            <Inspector />
+                            <Compare />
+                            <Console />
                         </Grid>
                         <Grid item xs={12} lg={4}>
                             <Schema />
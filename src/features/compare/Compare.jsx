@@ -0,0 +1,110 @@
+import React, { useEffect, useState } from 'react';
+import { useSelector } from 'react-redux';
+import axios from 'axios';
+
+import Accordion from '@mui/material/Accordion';
+import AccordionDetails from '@mui/material/AccordionDetails';
+import AccordionSummary from '@mui/material/AccordionSummary';
+import Button from '@mui/material/Button';
+import Grid from '@mui/material/Grid';
+import MenuItem from '@mui/material/MenuItem';
+import Select from '@mui/material/Select';
+import Stack from '@mui/material/Stack';
+import TextField from '@mui/material/TextField';
+import Typography from '@mui/material/Typography';
+
+// Parses sizes written as WIDTHxHEIGHT, separated by spaces or commas.
+function parseSizes(value) {
+    return value.split(/[\s,]+/).filter(s => s).map(size => {
+        const [width, height] = size.split('x').map(Number);
+        if (!width || !height) {
+            throw new Error(`invalid size ${size}, expected WIDTHxHEIGHT`);
+        }
+        return { width, height };
+    });
+}
+
+// Compare renders the app with several configs or display sizes at
+// once, starting from the current config.
+export default function Compare() {
+    const config = useSelector(state => state.config);
+    const schema = useSelector(state => state.schema);
+    const preview = useSelector(state => state.preview);
+
+    const [expanded, setExpanded] = useState(false);
+    const [vary, setVary] = useState('');
+    const [sizes, setSizes] = useState('');
+    const [variants, setVariants] = useState(null);
+    const [error, setError] = useState('');
+
+    const fields = schema.value.schema.filter(field => ['dropdown', 'radio', 'onoff'].includes(field.type));
+
+    function run() {
+        setError('');
+
+        const req = {
+            config: Object.fromEntries(Object.values(config).map(item => [item.id, item.value])),
+            fixtures: vary === 'fixtures',
+            field: vary.startsWith('field:') ? vary.slice(6) : '',
+        };
+        try {
+            req.sizes = parseSizes(sizes);
+        } catch (e) {
+            setError(e.message);
+            return;
+        }
+
+        axios.post(`${PIXLET_API_BASE}/api/v1/compare`, req)
+            .then(res => setVariants(res.data.variants))
+            .catch(err => setError(err.response ? err.response.data : err.message));
+    }
+
+    // Render the comparison again after the app changes.
+    useEffect(() => {
+        if (expanded && variants) {
+            run();
+        }
+    }, [preview]);
+
+    return (
+        <Accordion sx={{ marginTop: '32px' }} expanded={expanded} onChange={(e, open) => setExpanded(open)}>
+            <AccordionSummary>
+                <Typography>Compare</Typography>
+            </AccordionSummary>
+            <AccordionDetails>
+                <Stack spacing={2} direction="row" alignItems="center">
+                    <Select size="small" value={vary} displayEmpty onChange={e => setVary(e.target.value)}>
+                        <MenuItem value="">Vary nothing</MenuItem>
+                        <MenuItem value="fixtures">Vary fixtures</MenuItem>
+                        {fields.map(field => (
+                            <MenuItem key={field.id} value={`field:${field.id}`}>Vary {field.name || field.id}</MenuItem>
+                        ))}
+                    </Select>
+                    <TextField
+                        size="small"
+                        label="Sizes"
+                        placeholder="64x32 128x64"
+                        value={sizes}
+                        onChange={e => setSizes(e.target.value)}
+                    />
+                    <Button variant="outlined" onClick={run}>Compare</Button>
+                </Stack>
+                {error && <Typography color="error" sx={{ marginTop: '16px' }}>{error}</Typography>}
+                <Grid container spacing={2} sx={{ marginTop: '8px' }}>
+                    {(variants || []).map((v, i) => (
+                        <Grid item xs={12} md={6} key={i}>
+                            <Typography sx={{ fontFamily: 'monospace' }}>{v.name || 'current config'}</Typography>
+                            {v.error ?
+                                <Typography color="error" sx={{ fontFamily: 'monospace', whiteSpace: 'pre-wrap' }}>{v.error}</Typography> :
+                                <img
+                                    src={`data:image/${v.img_type};base64,${v.img}`}
+                                    style={{ width: '100%', imageRendering: 'pixelated' }}
+                                />
+                            }
+                        </Grid>
+                    ))}
+                </Grid>
+            </AccordionDetails>
+        </Accordion>
+    );
+}
@@ -0,0 +1,42 @@
+import React, { useEffect, useRef } from 'react';
+import { useSelector, useDispatch } from 'react-redux';
+
+import Accordion from '@mui/material/Accordion';
+import AccordionDetails from '@mui/material/AccordionDetails';
+import AccordionSummary from '@mui/material/AccordionSummary';
+import Box from '@mui/material/Box';
+import Button from '@mui/material/Button';
+import Typography from '@mui/material/Typography';
+
+import { clear } from './consoleSlice';
+
+export default function Console() {
+    const entries = useSelector(state => state.console.entries);
+    const dispatch = useDispatch();
+    const bottom = useRef(null);
+
+    useEffect(() => {
+        if (bottom.current) {
+            bottom.current.scrollIntoView({ block: 'nearest' });
+        }
+    }, [entries]);
+
+    return (
+        <Accordion sx={{ marginTop: '32px' }}>
+            <AccordionSummary>
+                <Typography>Console ({entries.length})</Typography>
+            </AccordionSummary>
+            <AccordionDetails>
+                <Box component="pre" sx={{ maxHeight: '20em', overflowY: 'auto', margin: 0 }}>
+                    {entries.map((entry, i) =>
+                        <div key={i}>
+                            {new Date(entry.time).toLocaleTimeString()} [{entry.kind}] {entry.message}
+                        </div>
+                    )}
+                    <div ref={bottom} />
+                </Box>
+                <Button variant="outlined" onClick={() => dispatch(clear())}>Clear</Button>
+            </AccordionDetails>
+        </Accordion>
+    );
+}
@@ -0,0 +1,26 @@
+import { createSlice } from '@reduxjs/toolkit';
+
+// The most recent entries kept in the console.
+const maxEntries = 500;
+
+export const consoleSlice = createSlice({
+    name: 'console',
+    initialState: {
+        entries: [],
+    },
+    reducers: {
+        append: (state, action) => {
+            return {
+                entries: state.entries.concat(action.payload).slice(-maxEntries),
+            }
+        },
+        clear: () => {
+            return {
+                entries: [],
+            }
+        },
+    },
+});
+
+export const { append, clear } = consoleSlice.actions;
+export default consoleSlice.reducer;
@@ -0,0 +1,192 @@
+import React, { useEffect, useRef, useState } from 'react';
+import { useSelector } from 'react-redux';
+import axios from 'axios';
+
+import Accordion from '@mui/material/Accordion';
+import AccordionDetails from '@mui/material/AccordionDetails';
+import AccordionSummary from '@mui/material/AccordionSummary';
+import Button from '@mui/material/Button';
+import Checkbox from '@mui/material/Checkbox';
+import FormControlLabel from '@mui/material/FormControlLabel';
+import MenuItem from '@mui/material/MenuItem';
+import Select from '@mui/material/Select';
+import Slider from '@mui/material/Slider';
+import Stack from '@mui/material/Stack';
+import Typography from '@mui/material/Typography';
+
+// Outline colors for each level of nesting, as used by
+// pixlet render --debug-layout.
+const layoutColors = ['#ff00ff', '#00ffff', '#ffff00', '#00ff00', '#ff8000', '#8080ff'];
+
+// Inspector steps through the frames of the last render, served one by
+// one by the frames API.
+export default function Inspector() {
+    const preview = useSelector(state => state.preview);
+    const canvas = useRef(null);
+
+    const [expanded, setExpanded] = useState(false);
+    const [meta, setMeta] = useState(null);
+    const [images, setImages] = useState([]);
+    const [index, setIndex] = useState(0);
+    const [playing, setPlaying] = useState(false);
+    const [zoom, setZoom] = useState(8);
+    const [showBounds, setShowBounds] = useState(true);
+    const [showLayout, setShowLayout] = useState(false);
+    const [pixel, setPixel] = useState('');
+
+    const frameCount = meta ? meta.frame_count : 0;
+
+    // Load the frames of every new render while expanded.
+    useEffect(() => {
+        if (!expanded) {
+            return;
+        }
+
+        const query = showLayout ? '?layout=true' : '';
+        axios.get(`${PIXLET_API_BASE}/api/v1/frames${query}`).then(res => {
+            const t = Date.now();
+            const loaded = res.data.frames.map((_, i) => {
+                const img = new Image();
+                img.src = `${PIXLET_API_BASE}/api/v1/frames/${i}.png?t=${t}`;
+                return img;
+            });
+            loaded.forEach(img => img.onload = () => setImages([...loaded]));
+
+            setMeta(res.data);
+            setImages(loaded);
+            setIndex(i => Math.min(i, Math.max(res.data.frame_count - 1, 0)));
+            setPlaying(res.data.frame_count > 1);
+        });
+    }, [expanded, preview, showLayout]);
+
+    useEffect(() => {
+        if (!playing || frameCount < 2) {
+            return;
+        }
+
+        const timer = setTimeout(() => {
+            setIndex(i => (i + 1) % frameCount);
+        }, meta.frames[index].delay);
+        return () => clearTimeout(timer);
+    }, [playing, index, meta]);
+
+    useEffect(() => {
+        if (!canvas.current || frameCount === 0) {
+            return;
+        }
+
+        const ctx = canvas.current.getContext('2d');
+        canvas.current.width = meta.width * zoom;
+        canvas.current.height = meta.height * zoom;
+        ctx.imageSmoothingEnabled = false;
+
+        const img = images[index];
+        if (img && img.complete && img.naturalWidth > 0) {
+            ctx.drawImage(img, 0, 0, canvas.current.width, canvas.current.height);
+        }
+
+        ctx.lineWidth = 1;
+        if (showLayout) {
+            // Widgets are outlined in the color of their depth
+            (meta.frames[index].widgets || []).forEach(w => {
+                const b = w.bounds;
+                ctx.strokeStyle = layoutColors[w.depth % layoutColors.length];
+                ctx.strokeRect(b.x * zoom + 0.5, b.y * zoom + 0.5, b.width * zoom - 1, b.height * zoom - 1);
+            });
+        } else if (showBounds) {
+            const b = meta.frames[index].bounds;
+            ctx.strokeStyle = 'magenta';
+            ctx.strokeRect(b.x * zoom + 0.5, b.y * zoom + 0.5, b.width * zoom - 1, b.height * zoom - 1);
+        }
+    }, [meta, images, index, zoom, showBounds, showLayout]);
+
+    function step(n) {
+        if (frameCount === 0) {
+            return;
+        }
+        setPlaying(false);
+        setIndex(i => (i + n + frameCount) % frameCount);
+    }
+
+    function readPixel(e) {
+        const img = images[index];
+        if (!img || !img.complete || img.naturalWidth === 0) {
+            return;
+        }
+
+        const rect = canvas.current.getBoundingClientRect();
+        const x = Math.floor((e.clientX - rect.left) / zoom);
+        const y = Math.floor((e.clientY - rect.top) / zoom);
+        if (x < 0 || y < 0 || x >= meta.width || y >= meta.height) {
+            return;
+        }
+
+        // Frames may have been scaled up by filters, so pixels are read
+        // from the frame itself.
+        const scale = img.naturalWidth / meta.width;
+        const c = document.createElement('canvas');
+        c.width = c.height = 1;
+        const ctx = c.getContext('2d');
+        ctx.drawImage(img, Math.floor(x * scale), Math.floor(y * scale), 1, 1, 0, 0, 1, 1);
+        const [r, g, b, a] = ctx.getImageData(0, 0, 1, 1).data;
+        const hex = '#' + [r, g, b].map(v => v.toString(16).padStart(2, '0')).join('');
+
+        // List the widgets covering the pixel, outermost first
+        const widgets = (meta.frames[index].widgets || [])
+            .filter(w => x >= w.bounds.x && x < w.bounds.x + w.bounds.width &&
+                y >= w.bounds.y && y < w.bounds.y + w.bounds.height)
+            .map(w => w.type);
+
+        setPixel(`(${x}, ${y}) ${hex} rgba(${r}, ${g}, ${b}, ${a})` +
+            (widgets.length > 0 ? ` ${widgets.join(' > ')}` : ''));
+    }
+
+    let info = 'No frames';
+    if (frameCount > 0) {
+        const frame = meta.frames[index];
+        const b = frame.bounds;
+        info = `frame ${index + 1}/${frameCount}, delay ${frame.delay}ms, ` +
+            `bounds ${b.width}x${b.height} at (${b.x}, ${b.y})`;
+    }
+
+    return (
+        <Accordion sx={{ marginTop: '32px' }} expanded={expanded} onChange={(e, open) => setExpanded(open)}>
+            <AccordionSummary>
+                <Typography>Frames</Typography>
+            </AccordionSummary>
+            <AccordionDetails>
+                <Stack spacing={2} direction="row" alignItems="center">
+                    <Button variant="outlined" onClick={() => setPlaying(!playing)}>{playing ? 'Pause' : 'Play'}</Button>
+                    <Button variant="outlined" onClick={() => step(-1)}>&lsaquo;</Button>
+                    <Button variant="outlined" onClick={() => step(1)}>&rsaquo;</Button>
+                    <Slider
+                        min={0}
+                        max={Math.max(frameCount - 1, 0)}
+                        value={index}
+                        onChange={(e, value) => { setPlaying(false); setIndex(value); }}
+                    />
+                    <Select size="small" value={zoom} onChange={e => setZoom(e.target.value)}>
+                        <MenuItem value={4}>4x</MenuItem>
+                        <MenuItem value={8}>8x</MenuItem>
+                        <MenuItem value={16}>16x</MenuItem>
+                    </Select>
+                    <FormControlLabel
+                        control={<Checkbox checked={showBounds} onChange={e => setShowBounds(e.target.checked)} />}
+                        label="Bounds"
+                    />
+                    <FormControlLabel
+                        control={<Checkbox checked={showLayout} onChange={e => setShowLayout(e.target.checked)} />}
+                        label="Layout"
+                    />
+                </Stack>
+                <canvas
+                    ref={canvas}
+                    onMouseMove={readPixel}
+                    style={{ imageRendering: 'pixelated', cursor: 'crosshair', marginTop: '16px' }}
+                />
+                <Typography sx={{ fontFamily: 'monospace' }}>{info}</Typography>
+                <Typography sx={{ fontFamily: 'monospace' }}>{pixel}</Typography>
+            </AccordionDetails>
+        </Accordion>
+    );
+}
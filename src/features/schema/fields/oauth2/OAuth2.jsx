@@ -13,7 +13,7 @@ export default function OAuth2({ field }) {
     const [loggedIn, setLoggedIn] = useState("");
     const dispatch = useDispatch();
     const config = useSelector(state => state.config);
-    const redirectUri = document.location.protocol + "//" + document.location.host + "/oauth-callback"
+    const redirectUri = new URL('oauth-callback', document.baseURI).href
 
     useEffect(() => {
         if (field.id in config) {
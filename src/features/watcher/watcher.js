@@ -2,6 +2,7 @@ import store from '../../store';
 import { update } from '../preview/previewSlice';
 import { update as updateSchema } from '../schema/schemaSlice';
 import { set as setError, clear as clearErrors } from '../errors/errorSlice';
+import { append as appendLogs } from '../console/consoleSlice';
 
 export default class Watcher {
     constructor() {
@@ -9,8 +10,9 @@ export default class Watcher {
     }
 
     connect() {
-        const proto = document.location.protocol === "https:" ? "wss:" : "ws:";
-        this.conn = new WebSocket(proto + '//' + document.location.host + '/api/v1/ws');
+        const url = new URL('api/v1/ws', document.baseURI);
+        url.protocol = document.location.protocol === "https:" ? "wss:" : "ws:";
+        this.conn = new WebSocket(url);
         this.conn.open = this.open.bind(this);
         this.conn.onmessage = this.process.bind(this);
         this.conn.onclose = this.close.bind(this);
@@ -40,6 +42,9 @@ export default class Watcher {
             case 'error':
                 store.dispatch(setError({ id: data.message, message: data.message }));
                 break;
+            case 'log':
+                store.dispatch(appendLogs(JSON.parse(data.message)));
+                break;
             default:
                 console.log(`[watcher] unknown type ${data.type}`);
         }
@@ -8,11 +8,16 @@ import OAuth2Handler from './features/schema/fields/oauth2/OAuth2Handler';
 import store from './store';
 import DevToolsTheme from './features/theme/DevToolsTheme';
 
+// The server sets a base when the frontend is served under a path, such
+// as for an app in a workspace. Routes are matched below it.
+const base = document.querySelector('base');
+const basename = base ? new URL(base.href).pathname.replace(/\/$/, '') : '';
+
 const App = () => {
     return (
         <Provider store={store}>
             <DevToolsTheme>
-                <BrowserRouter>
+                <BrowserRouter basename={basename}>
                     <Routes>
                         <Route exact path="/" element={<Main />} />
                         <Route path="oauth-callback" element={<OAuth2Handler />} />
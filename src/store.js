@@ -1,6 +1,7 @@
 import { configureStore } from '@reduxjs/toolkit'
 
 import configSlice from './features/config/configSlice';
+import consoleSlice from './features/console/consoleSlice';
 import errorSlice from './features/errors/errorSlice';
 import handlerSlice from './features/handlers/handlerSlice';
 import paramSlice from './features/config/paramSlice';
@@ -10,6 +11,7 @@ import schemaSlice from './features/schema/schemaSlice';
 export default configureStore({
     reducer: {
         config: configSlice,
+        console: consoleSlice,
         errors: errorSlice,
         handlers: handlerSlice,
         param: paramSlice,
@@ -0,0 +1,29 @@
+package starlarkutil
+
+import (
+	"time"
+
+	starlibtime "go.starlark.net/lib/time"
+	"go.starlark.net/starlark"
+)
+
+// AttachThreadClock makes a Starlark thread see now as the current
+// time. It's what `time.now()` returns, and what `ThreadNow` returns
+// for other modules.
+func AttachThreadClock(now func() time.Time, thread *starlark.Thread) {
+	starlibtime.SetNow(thread, func() (time.Time, error) {
+		return now(), nil
+	})
+}
+
+// ThreadNow returns the current time, as seen by a Starlark thread. If
+// no clock is attached to the thread with `AttachThreadClock`, it
+// returns the actual time.
+func ThreadNow(thread *starlark.Thread) time.Time {
+	if now := starlibtime.Now(thread); now != nil {
+		if t, err := now(); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
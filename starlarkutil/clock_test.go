@@ -0,0 +1,28 @@
+package starlarkutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	starlibtime "go.starlark.net/lib/time"
+	"go.starlark.net/starlark"
+)
+
+func TestThreadClock(t *testing.T) {
+	now := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)
+
+	thread := &starlark.Thread{}
+	AttachThreadClock(func() time.Time { return now }, thread)
+	assert.Equal(t, now, ThreadNow(thread))
+
+	// time.now() sees the same clock
+	val, err := starlark.Call(thread, starlibtime.Module.Members["now"], nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, starlibtime.Time(now), val)
+}
+
+func TestThreadWithoutClock(t *testing.T) {
+	thread := &starlark.Thread{}
+	assert.WithinDuration(t, time.Now(), ThreadNow(thread), time.Minute)
+}
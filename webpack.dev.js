@@ -20,7 +20,9 @@ const copyPlugin = new CopyWebpackPlugin({
 let plugins = [htmlPlugin, copyPlugin];
 plugins.push(
     new webpack.DefinePlugin({
-        'PIXLET_API_BASE': JSON.stringify(''),
+        // API requests are relative to the page's base, so that the
+        // frontend also works when served under a path.
+        'PIXLET_API_BASE': JSON.stringify('.'),
     })
 );
 
@@ -21,7 +21,9 @@ const copyPlugin = new CopyWebpackPlugin({
 let plugins = [htmlPlugin, copyPlugin];
 plugins.push(
     new webpack.DefinePlugin({
-        'PIXLET_API_BASE': JSON.stringify(''),
+        // API requests are relative to the page's base, so that the
+        // frontend also works when served under a path.
+        'PIXLET_API_BASE': JSON.stringify('.'),
     })
 );
 
@@ -30,7 +32,7 @@ module.exports = merge(common, {
     devtool: 'source-map',
     output: {
         asyncChunks: true,
-        publicPath: '/static/',
+        publicPath: 'static/',
         path: path.resolve(__dirname, 'dist/static'),
         filename: '[name].[chunkhash].js',
         clean: true,